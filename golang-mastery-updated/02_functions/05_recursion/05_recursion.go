@@ -32,8 +32,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"math"
 	"strings"
+	"sync"
+	"testing"
 )
 
 // ─── 1. FACTORIAL — CLASSIC EXAMPLE ──────────────────────────────────────────
@@ -275,9 +279,104 @@ func flatten(nested []any) []int {
 	return result
 }
 
+// ─── 9. FIBONACCI IN uint64 — OVERFLOW LIMIT AND BENCHMARKS ──────────────────
+//
+// The int versions above silently wrap once the result no longer fits.
+// With uint64 the exact limit is known:
+//   fib(93) = 12200160415121876738   ← largest that fits (MaxUint64 ≈ 1.8e19)
+//   fib(94) = 19740274219868223167   ← overflows
+// FibIter and FibMemo SATURATE: any n > 93 returns math.MaxUint64, so an
+// overflow can never masquerade as a plausible small number. n < 0 returns 0.
+
+const maxFibN = 93
+
+// FibIter — O(n) time, O(1) space.
+func FibIter(n int) uint64 {
+	switch {
+	case n <= 0:
+		return 0
+	case n > maxFibN:
+		return math.MaxUint64
+	}
+	var a, b uint64 = 0, 1
+	for i := 2; i <= n; i++ {
+		a, b = b, a+b
+	}
+	return b
+}
+
+// FibMemo — the recursive definition plus a memo table. Since n is capped at
+// 93 the table is a fixed-size package-level array, filled ONCE by the
+// recursion on first use and shared by every later call: after that, each
+// call is a single lookup. sync.Once makes the fill safe if the first calls
+// race from several goroutines.
+var (
+	fibMemoOnce  sync.Once
+	fibMemoTable [maxFibN + 1]uint64
+)
+
+func FibMemo(n int) uint64 {
+	switch {
+	case n <= 0:
+		return 0
+	case n > maxFibN:
+		return math.MaxUint64
+	}
+	fibMemoOnce.Do(func() {
+		var fib func(int) uint64
+		fib = func(k int) uint64 {
+			if k <= 1 {
+				return uint64(k)
+			}
+			if fibMemoTable[k] == 0 {
+				fibMemoTable[k] = fib(k-1) + fib(k-2)
+			}
+			return fibMemoTable[k]
+		}
+		fibMemoTable[1] = 1 // base cases: fib never stores them
+		fib(maxFibN)        // fills every entry from 2 up on the way down
+	})
+	return fibMemoTable[n]
+}
+
+// benchFlag gates the benchmarks: testing.Benchmark runs each function for
+// about a second, too slow for a plain `go run` of the lesson.
+var benchFlag = flag.Bool("bench", false, "run the Fibonacci benchmarks")
+
+// fibSink keeps benchmark results alive so the compiler can't drop the calls.
+var fibSink uint64
+
+// benchFib runs fn(n) under testing.Benchmark — the same harness `go test
+// -bench` uses, callable from an ordinary program.
+func benchFib(fn func(int) uint64, n int) testing.BenchmarkResult {
+	return testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fibSink = fn(n)
+		}
+	})
+}
+
+// benchFibs compares the three approaches at n=35.
+func benchFibs() {
+	fmt.Println("  benchmarking n=35 (takes a few seconds)...")
+	naive35 := func(n int) uint64 { return uint64(fibNaive(n)) }
+	for _, bc := range []struct {
+		name string
+		fn   func(int) uint64
+	}{
+		{"naive recursion", naive35},
+		{"FibIter", FibIter},
+		{"FibMemo", FibMemo},
+	} {
+		r := benchFib(bc.fn, 35)
+		fmt.Printf("  %-16s %12d ns/op  (%d runs)\n", bc.name, r.NsPerOp(), r.N)
+	}
+}
+
 // ─── MAIN ─────────────────────────────────────────────────────────────────────
 
 func main() {
+	flag.Parse()
 	sep := strings.Repeat("═", 55)
 	fmt.Println(sep)
 	fmt.Println("  RECURSION IN GO")
@@ -361,6 +460,25 @@ func main() {
 	fmt.Printf("  nested: %v\n", nested)
 	fmt.Printf("  flat:   %v\n", flatten(nested))
 
+	// 9. uint64 Fibonacci — limits and benchmarks
+	fmt.Println("\n── 9. FibIter / FibMemo (uint64) — Limits and Benchmarks ──")
+	agree := true
+	for n := 0; n <= 30; n++ {
+		want := uint64(fibNaive(n))
+		agree = agree && FibIter(n) == want && FibMemo(n) == want
+	}
+	fmt.Printf("  naive, FibIter and FibMemo agree for n=0..30: %v\n", agree)
+	for _, n := range []int{92, 93, 94, 200, -1} {
+		fmt.Printf("  n=%3d: FibIter=%-20d FibMemo=%-20d saturated=%v\n",
+			n, FibIter(n), FibMemo(n), FibIter(n) == math.MaxUint64)
+	}
+
+	if !*benchFlag {
+		fmt.Println("  benchmarks skipped — run with -bench to compare (takes a few seconds)")
+	} else {
+		benchFibs()
+	}
+
 	fmt.Println("\n" + sep)
 	fmt.Println("Key Takeaways:")
 	fmt.Println("  • Every recursion needs a base case — missing one = infinite loop/crash")
@@ -369,6 +487,7 @@ func main() {
 	fmt.Println("  • Prefer iteration for large inputs (factorial, fibonacci, sum)")
 	fmt.Println("  • Prefer recursion for recursive data structures (trees, nested data)")
 	fmt.Println("  • Memoization transforms O(2^n) naive recursion to O(n)")
+	fmt.Println("  • uint64 holds fib(n) up to n=93 — saturate or error beyond, never wrap")
 	fmt.Println("  • Mutual recursion works naturally in Go (package-level visibility)")
 	fmt.Println(sep)
 }
@@ -439,6 +439,87 @@ func section9ArraySliceConversion() {
 	fmt.Println()
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// SECTION 10: Checked slice → array conversion
+// ─────────────────────────────────────────────────────────────────────────────
+//
+// [N]T(s) compiles for any slice, but PANICS at runtime if len(s) < N and
+// silently ignores the extra elements if len(s) > N. For data from outside
+// (a parsed IP, a hash read off the wire) an error is friendlier than both.
+//
+// Why ToArray4 / ToArray16 / ToArray32 and not one ToArrayN[T, N]?
+// An array's length must be a compile-time CONSTANT, and type parameters can
+// only stand for types — Go has no "const generics". So each size gets its
+// own small function. Supported sizes here:
+//   4  → IPv4 addresses
+//   16 → IPv6 addresses, UUIDs
+//   32 → SHA-256 digests (sha256.Sum256 returns [32]byte)
+
+func checkArrayLen(want, got int) error {
+	if got != want {
+		return fmt.Errorf("slice length %d does not match array length %d", got, want)
+	}
+	return nil
+}
+
+// ToArray4 copies s into a [4]T; len(s) must be exactly 4.
+func ToArray4[T any](s []T) ([4]T, error) {
+	if err := checkArrayLen(4, len(s)); err != nil {
+		return [4]T{}, err
+	}
+	return [4]T(s), nil
+}
+
+// ToArray16 copies s into a [16]T; len(s) must be exactly 16.
+func ToArray16[T any](s []T) ([16]T, error) {
+	if err := checkArrayLen(16, len(s)); err != nil {
+		return [16]T{}, err
+	}
+	return [16]T(s), nil
+}
+
+// ToArray32 copies s into a [32]T; len(s) must be exactly 32.
+func ToArray32[T any](s []T) ([32]T, error) {
+	if err := checkArrayLen(32, len(s)); err != nil {
+		return [32]T{}, err
+	}
+	return [32]T(s), nil
+}
+
+func section10CheckedConversion() {
+	fmt.Println("=== SECTION 10: Checked Slice → Array Conversion ===")
+
+	ip := []byte{192, 168, 1, 10}
+	arr, err := ToArray4(ip)
+	fmt.Printf("ToArray4(%v): %v err=%v\n", ip, arr, err)
+
+	// The array is a COPY (value type) — the slice is unaffected:
+	arr[3] = 99
+	fmt.Printf("After arr[3]=99: arr=%v, slice still %v\n", arr, ip)
+
+	_, err = ToArray4([]byte{10, 0, 1})
+	fmt.Printf("Too short (3): err=%v\n", err)
+	_, err = ToArray4([]byte{10, 0, 0, 1, 5})
+	fmt.Printf("Too long (5):  err=%v\n", err)
+
+	uuid, err := ToArray16(make([]byte, 16))
+	fmt.Printf("ToArray16 (UUID-sized): len=%d err=%v\n", len(uuid), err)
+
+	digest := sha256.Sum256([]byte("hello"))
+	fromWire := digest[:] // e.g. hex-decoded from a request
+	back, err := ToArray32(fromWire)
+	fmt.Printf("ToArray32 round-trip equals original: %v err=%v\n", back == digest, err)
+
+	// Compare: the raw conversion panics on a short slice.
+	func() {
+		defer func() { fmt.Printf("[4]byte(short) panicked: %v\n", recover() != nil) }()
+		short := []byte{1, 2}
+		_ = [4]byte(short)
+	}()
+
+	fmt.Println()
+}
+
 func main() {
 	fmt.Println("╔══════════════════════════════════════════════════════╗")
 	fmt.Println("║           Go Arrays: Deep Dive                       ║")
@@ -453,6 +534,7 @@ func main() {
 	section6Iteration()
 	section8WhenArraysAreRight()
 	section9ArraySliceConversion()
+	section10CheckedConversion()
 
 	fmt.Println("╔══════════════════════════════════════════════════════╗")
 	fmt.Println("║  KEY TAKEAWAYS                                       ║")
@@ -1,11 +1,14 @@
 // FILE: 05_collections/08_collections_patterns.go
-// TOPIC: Collection Patterns — stack, queue, set ops, dedup, partition, groupBy
+// TOPIC: Collection Patterns — stack, queue, set ops, dedup, partition, groupBy, trie
 //
 // Run: go run 05_collections/08_collections_patterns.go
 
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 // ── STACK (LIFO) using slice ──────────────────────────────────────────────────
 type Stack[T any] struct {
@@ -48,6 +51,85 @@ func (q *Queue[T]) Dequeue() (T, bool) {
 }
 func (q *Queue[T]) Len() int { return len(q.items) }
 
+// ── TRIE (prefix tree) using nested maps ──────────────────────────────────────
+// Each node maps the NEXT rune to a child node; a word is the path from the
+// root plus an end-of-word flag. Lookup cost depends on the word length, not
+// on how many words are stored — ideal for autocomplete.
+//
+// Children are keyed by rune, not byte, so "café" is 4 edges, and a prefix
+// can never end in the middle of a multibyte character.
+//
+//   insert "go", "gopher", "golang":   root ─g─ o* ─p─ h─e─r*
+//                                                 └─l─ a─n─g*     (* = end)
+type trieNode struct {
+	children map[rune]*trieNode
+	end      bool
+}
+
+type Trie struct {
+	root trieNode
+	size int
+}
+
+func (t *Trie) Insert(word string) {
+	n := &t.root
+	for _, r := range word {
+		if n.children == nil {
+			n.children = make(map[rune]*trieNode)
+		}
+		child, ok := n.children[r]
+		if !ok {
+			child = &trieNode{}
+			n.children[r] = child
+		}
+		n = child
+	}
+	if !n.end {
+		n.end = true
+		t.size++
+	}
+}
+
+// find walks prefix and returns its node, or nil if the path doesn't exist.
+func (t *Trie) find(prefix string) *trieNode {
+	n := &t.root
+	for _, r := range prefix {
+		n = n.children[r] // reading a nil map is fine: returns nil
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+func (t *Trie) Contains(word string) bool {
+	n := t.find(word)
+	return n != nil && n.end
+}
+
+func (t *Trie) HasPrefix(prefix string) bool { return t.find(prefix) != nil }
+
+func (t *Trie) Len() int { return t.size }
+
+// WordsWithPrefix returns every stored word starting with prefix, sorted.
+func (t *Trie) WordsWithPrefix(prefix string) []string {
+	var words []string
+	var walk func(n *trieNode, path []rune)
+	walk = func(n *trieNode, path []rune) {
+		if n.end {
+			words = append(words, string(path))
+		}
+		for r, child := range n.children {
+			walk(child, append(path, r))
+		}
+	}
+	if n := t.find(prefix); n != nil {
+		walk(n, []rune(prefix))
+	}
+	sort.Strings(words) // map iteration order is random
+	return words
+}
+
 func main() {
 	fmt.Println("════════════════════════════════════════")
 	fmt.Println("  Topic: Collection Patterns")
@@ -163,10 +245,29 @@ func main() {
 		fmt.Printf("  %s: %v\n", cat, grouped[cat])
 	}
 
+	// ── TRIE ──────────────────────────────────────────────────────────
+	fmt.Println("\n── Trie ──")
+	trie := &Trie{}
+	for _, w := range []string{"go", "gopher", "golang", "gob", "google", "go", "café", "cafe", "日本", "日本語"} {
+		trie.Insert(w)
+	}
+	fmt.Printf("  %d distinct words (\"go\" inserted twice)\n", trie.Len())
+	fmt.Printf("  Contains(go)=%v Contains(gop)=%v HasPrefix(gop)=%v\n",
+		trie.Contains("go"), trie.Contains("gop"), trie.HasPrefix("gop"))
+	fmt.Printf("  WordsWithPrefix(go):  %v\n", trie.WordsWithPrefix("go"))
+	fmt.Printf("  WordsWithPrefix(gol): %v\n", trie.WordsWithPrefix("gol"))
+	fmt.Printf("  WordsWithPrefix(caf): %v\n", trie.WordsWithPrefix("caf"))
+	fmt.Printf("  Contains(café)=%v Contains(日本)=%v HasPrefix(日)=%v\n",
+		trie.Contains("café"), trie.Contains("日本"), trie.HasPrefix("日"))
+	fmt.Printf("  WordsWithPrefix(日本): %v\n", trie.WordsWithPrefix("日本"))
+	fmt.Printf("  WordsWithPrefix(rust): %v\n", trie.WordsWithPrefix("rust"))
+	fmt.Printf("  WordsWithPrefix(\"\"): %d words (everything)\n", len(trie.WordsWithPrefix("")))
+
 	fmt.Println("\n─── SUMMARY ────────────────────────────────")
 	fmt.Println("  Stack: append to push, slice[:n-1] to pop")
 	fmt.Println("  Queue: append to enqueue, slice[1:] to dequeue")
 	fmt.Println("  Set ops: use map[T]struct{} for union/intersection/diff")
 	fmt.Println("  Dedup: map to track seen items, preserve order")
 	fmt.Println("  Partition/GroupBy: foundational slice+map patterns")
+	fmt.Println("  Trie: map[rune]*node per level, prefix lookups in O(len(prefix))")
 }
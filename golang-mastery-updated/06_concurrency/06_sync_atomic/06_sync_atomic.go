@@ -6,11 +6,78 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
+// ── AtomicValue[T] — hot-swappable value without atomic.Value's traps ────────
+// atomic.Value stores `any`, so every Load needs a .(Config) assertion, and
+// two rules are only checked at RUNTIME (both panic):
+//   1. every Store must use the SAME concrete type as the first one
+//   2. Store(nil) is not allowed
+// AtomicValue[T] is built on atomic.Pointer[T] instead: each Store boxes the
+// value in a fresh *T and swaps the pointer. The pointer's type never
+// changes, so neither rule applies — AtomicValue[error] may hold a
+// *os.PathError, then a *url.Error, then nil.
+//
+// Each Store swaps in a whole new value; readers see either the old value or
+// the new one, never a half-written mix. Treat loaded values as read-only.
+//
+// The zero AtomicValue behaves as if it holds T's zero value: Load returns
+// it, and CompareAndSwap(zero, x) succeeds. CompareAndSwap compares VALUES
+// with ==, so it panics if T is not comparable (e.g. a struct containing a
+// slice or map).
+
+type AtomicValue[T any] struct {
+	p atomic.Pointer[T]
+}
+
+func NewAtomicValue[T any](initial T) *AtomicValue[T] {
+	a := &AtomicValue[T]{}
+	a.Store(initial)
+	return a
+}
+
+// Load returns the current value, or T's zero value if nothing was stored.
+func (a *AtomicValue[T]) Load() T {
+	if p := a.p.Load(); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+func (a *AtomicValue[T]) Store(val T) { a.p.Store(&val) }
+
+// Swap stores new and returns the previous value.
+func (a *AtomicValue[T]) Swap(new T) (old T) {
+	if p := a.p.Swap(&new); p != nil {
+		old = *p
+	}
+	return old
+}
+
+// CompareAndSwap stores new only if the current value == old. The pointer
+// CAS fails if another Store slipped in after the compare; then re-check.
+func (a *AtomicValue[T]) CompareAndSwap(old, new T) bool {
+	for {
+		p := a.p.Load()
+		var cur T
+		if p != nil {
+			cur = *p
+		}
+		if any(cur) != any(old) {
+			return false
+		}
+		if a.p.CompareAndSwap(p, &new) {
+			return true
+		}
+	}
+}
+
 func main() {
 	fmt.Println("════════════════════════════════════════")
 	fmt.Println("  Topic: sync/atomic")
@@ -75,9 +142,73 @@ func main() {
 	atomic.StoreInt64(&n, 100)
 	fmt.Printf("  After StoreInt64(100): %d\n", atomic.LoadInt64(&n))
 
+	// ── AtomicValue[T] ────────────────────────────────────────────────────
+	fmt.Println("\n── AtomicValue[T] (hot-swap under load) ──")
+	// Checksum must always equal Version*31 — a torn read would break it.
+	type Snapshot struct {
+		Version  int
+		Endpoint string
+		Checksum int
+	}
+	live := NewAtomicValue(Snapshot{Version: 1, Endpoint: "db-1", Checksum: 31})
+
+	var loads, torn atomic.Int64
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	for r := 0; r < 8; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				snap := live.Load() // no type assertion
+				if snap.Checksum != snap.Version*31 {
+					torn.Add(1)
+				}
+				loads.Add(1)
+			}
+		}()
+	}
+	for v := 2; v <= 500; v++ {
+		live.Store(Snapshot{Version: v, Endpoint: fmt.Sprintf("db-%d", v%3), Checksum: v * 31})
+		if v%50 == 0 {
+			time.Sleep(time.Millisecond) // let readers run between bursts
+		}
+	}
+	close(stop)
+	readers.Wait()
+	fmt.Printf("  499 stores, concurrent loads>0: %v, torn reads=%d, final version=%d\n",
+		loads.Load() > 0, torn.Load(), live.Load().Version)
+
+	prev := live.Swap(Snapshot{Version: 1000, Endpoint: "db-x", Checksum: 31000})
+	fmt.Printf("  Swap returned previous version %d\n", prev.Version)
+
+	mode := NewAtomicValue("read-write")
+	fmt.Printf("  CAS(\"read-write\"→\"read-only\"): %v, now %q\n",
+		mode.CompareAndSwap("read-write", "read-only"), mode.Load())
+	fmt.Printf("  CAS(\"read-write\"→\"maintenance\"): %v, still %q\n",
+		mode.CompareAndSwap("read-write", "maintenance"), mode.Load())
+
+	var empty AtomicValue[int]
+	fmt.Printf("  zero AtomicValue[int].Load(): %d\n", empty.Load())
+	fmt.Printf("  zero AtomicValue[int].CAS(0→7): %v, now %d\n", empty.CompareAndSwap(0, 7), empty.Load())
+
+	// Interface T: different concrete types and nil are all fine.
+	var lastErr AtomicValue[error]
+	lastErr.Store(fmt.Errorf("wrapped: %w", errors.New("disk full")))
+	lastErr.Store(errors.New("timeout")) // *errorString after *wrapError — no panic
+	fmt.Printf("  AtomicValue[error] after two concrete types: %v\n", lastErr.Load())
+	lastErr.Store(nil) // atomic.Value would panic here
+	fmt.Printf("  Store(nil): Load()=%v\n", lastErr.Load())
+
 	fmt.Println("\n─── SUMMARY ────────────────────────────────")
 	fmt.Println("  atomic.Int64 / Bool / Pointer → typed, preferred (Go 1.19+)")
 	fmt.Println("  atomic.Value → store any type, great for hot config")
+	fmt.Println("  AtomicValue[T] → atomic.Pointer[T] inside: any T, nil OK, zero value usable")
 	fmt.Println("  CompareAndSwap → conditional update (lock-free algorithms)")
 	fmt.Println("  Use atomics: single variable, read-heavy")
 	fmt.Println("  Use mutex: multiple related variables, complex invariants")
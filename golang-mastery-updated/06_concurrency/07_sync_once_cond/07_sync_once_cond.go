@@ -6,8 +6,11 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -32,6 +35,239 @@ func getDB() *Database {
 	return dbInstance
 }
 
+// ── Lazy[T] — the getDB pattern, generic and self-contained ──────────────────
+// getDB needs two package-level vars (the Once and the result). Lazy bundles
+// them: the value is computed on the first Get and every later Get returns
+// the cached result. Concurrent first callers block until fn finishes.
+
+type Lazy[T any] struct {
+	once  sync.Once
+	fn    func() T
+	value T
+}
+
+func NewLazy[T any](fn func() T) *Lazy[T] {
+	return &Lazy[T]{fn: fn}
+}
+
+func (l *Lazy[T]) Get() T {
+	l.once.Do(func() { l.value = l.fn() })
+	return l.value
+}
+
+// ── LazyErr[T] — lazy init that can fail ──────────────────────────────────────
+// sync.Once can't be reset, so a failed init would be cached forever.
+// LazyErr does NOT cache failures: if fn returns an error, that caller gets
+// the error and the NEXT Get runs fn again. Only a success is cached.
+// A mutex serializes attempts; the atomic flag keeps the hot path lock-free.
+
+type LazyErr[T any] struct {
+	mu    sync.Mutex
+	done  atomic.Bool
+	fn    func() (T, error)
+	value T
+}
+
+func NewLazyErr[T any](fn func() (T, error)) *LazyErr[T] {
+	return &LazyErr[T]{fn: fn}
+}
+
+func (l *LazyErr[T]) Get() (T, error) {
+	if l.done.Load() {
+		return l.value, nil // fast path: already initialized
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.done.Load() {
+		return l.value, nil // another goroutine won while we waited
+	}
+	v, err := l.fn()
+	if err != nil {
+		var zero T
+		return zero, err // not cached — next Get retries
+	}
+	l.value = v
+	l.done.Store(true)
+	return v, nil
+}
+
+// ── LazyGroup[K, V] — one Lazy per key ───────────────────────────────────────
+// Connection pools keyed by host, parsed templates keyed by name: each value
+// is built on first use and shared afterwards. LazyGroup keeps a Lazy[V]
+// per key. The group mutex is held only to find-or-create the entry, never
+// while init runs, so a slow init for "db-1" doesn't block Get("db-2");
+// concurrent first Gets for the SAME key wait on that key's Once.
+//
+// The init passed by the first caller for a key wins; later callers' init
+// funcs are ignored. Like Lazy, a panicking init leaves the zero value
+// cached. Entries are never evicted.
+
+type LazyGroup[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]*Lazy[V]
+}
+
+func (g *LazyGroup[K, V]) Get(key K, init func() V) V {
+	g.mu.Lock()
+	if g.entries == nil {
+		g.entries = make(map[K]*Lazy[V])
+	}
+	l, ok := g.entries[key]
+	if !ok {
+		l = NewLazy(init)
+		g.entries[key] = l
+	}
+	g.mu.Unlock()
+	return l.Get()
+}
+
+// ── BoundedQueue[T] — sync.Cond doing a channel's job, plus extras ───────────
+// A buffered channel already blocks when full/empty, but it can't tell you
+// what's at the head without removing it, and its Len/Cap are only
+// snapshots you can't act on atomically. Building the queue from a mutex and
+// two condition variables gives the same blocking behavior with full access:
+//
+//   Put  waits on notFull  while len == cap, then Signals notEmpty
+//   Take waits on notEmpty while len == 0,   then Signals notFull
+//
+// cond.Wait can't select on ctx.Done(), so a waiter would sleep through a
+// cancellation. context.AfterFunc fixes that: when ctx is done it Broadcasts,
+// every waiter wakes, re-checks ctx.Err() in its loop, and returns.
+
+type BoundedQueue[T any] struct {
+	mu       sync.Mutex
+	notFull  *sync.Cond
+	notEmpty *sync.Cond
+	items    []T // ring buffer
+	head     int
+	size     int
+}
+
+func NewBoundedQueue[T any](capacity int) *BoundedQueue[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	q := &BoundedQueue[T]{items: make([]T, capacity)}
+	q.notFull = sync.NewCond(&q.mu)
+	q.notEmpty = sync.NewCond(&q.mu)
+	return q
+}
+
+// wakeOnDone broadcasts c when ctx is cancelled; call the returned stop when done waiting.
+func (q *BoundedQueue[T]) wakeOnDone(ctx context.Context, c *sync.Cond) (stop func() bool) {
+	return context.AfterFunc(ctx, func() {
+		q.mu.Lock() // lock so the Broadcast can't slip in between a waiter's check and Wait
+		c.Broadcast()
+		q.mu.Unlock()
+	})
+}
+
+// Put appends v, blocking while the queue is full or until ctx is done.
+func (q *BoundedQueue[T]) Put(ctx context.Context, v T) error {
+	stop := q.wakeOnDone(ctx, q.notFull)
+	defer stop()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.size == len(q.items) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		q.notFull.Wait()
+	}
+	q.items[(q.head+q.size)%len(q.items)] = v
+	q.size++
+	q.notEmpty.Signal()
+	return nil
+}
+
+// Take removes the oldest item, blocking while the queue is empty or until ctx is done.
+func (q *BoundedQueue[T]) Take(ctx context.Context) (T, error) {
+	stop := q.wakeOnDone(ctx, q.notEmpty)
+	defer stop()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.size == 0 {
+		if err := ctx.Err(); err != nil {
+			var zero T
+			return zero, err
+		}
+		q.notEmpty.Wait()
+	}
+	v := q.items[q.head]
+	var zero T
+	q.items[q.head] = zero // don't keep a reference alive
+	q.head = (q.head + 1) % len(q.items)
+	q.size--
+	q.notFull.Signal()
+	return v, nil
+}
+
+// Peek returns the oldest item without removing it; ok is false when empty.
+func (q *BoundedQueue[T]) Peek() (v T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.size == 0 {
+		return v, false
+	}
+	return q.items[q.head], true
+}
+
+func (q *BoundedQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+func (q *BoundedQueue[T]) Cap() int { return len(q.items) }
+
+// ── Barrier — N goroutines meet, then all go ─────────────────────────────────
+// Phase-based work (simulation steps, parallel rounds of a computation) needs
+// every worker to finish round k before anyone starts round k+1. A WaitGroup
+// can't be reused safely while goroutines are still inside Wait; a Barrier
+// can.
+//
+// The `generation` counter is what makes it reusable: a waiter remembers the
+// generation it arrived in and sleeps until that generation ends. The last
+// arrival starts a new generation and Broadcasts. Without it, a fast
+// goroutine re-entering Wait for the next round could see count reset and
+// slip through, or an early waiter could miss its release.
+
+type Barrier struct {
+	mu         sync.Mutex
+	cond       *sync.Cond
+	n          int
+	count      int
+	generation uint64
+}
+
+func NewBarrier(n int) *Barrier {
+	if n < 1 {
+		panic("NewBarrier: n must be at least 1")
+	}
+	b := &Barrier{n: n}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// Wait blocks until n goroutines have called Wait in the current round.
+func (b *Barrier) Wait() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	gen := b.generation
+	b.count++
+	if b.count == b.n {
+		b.count = 0
+		b.generation++
+		b.cond.Broadcast()
+		return
+	}
+	for gen == b.generation {
+		b.cond.Wait()
+	}
+}
+
 func main() {
 	fmt.Println("════════════════════════════════════════")
 	fmt.Println("  Topic: sync.Once, sync.Cond, sync.Pool")
@@ -50,6 +286,71 @@ func main() {
 	}
 	wg.Wait()
 
+	// ── Lazy[T] ───────────────────────────────────────────────────────────
+	fmt.Println("\n── Lazy[T] (concurrent first access) ──")
+	var computeCalls atomic.Int32
+	config := NewLazy(func() map[string]string {
+		computeCalls.Add(1)
+		time.Sleep(10 * time.Millisecond) // simulate parsing a config file
+		return map[string]string{"env": "prod"}
+	})
+	var lazyWg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		lazyWg.Add(1)
+		go func() {
+			defer lazyWg.Done()
+			_ = config.Get()["env"]
+		}()
+	}
+	lazyWg.Wait()
+	fmt.Printf("  10 goroutines called Get, fn ran %d time(s), env=%s\n",
+		computeCalls.Load(), config.Get()["env"])
+
+	// ── LazyErr[T] ────────────────────────────────────────────────────────
+	fmt.Println("\n── LazyErr[T] (failures are retried, not cached) ──")
+	attempts := 0
+	conn := NewLazyErr(func() (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", errors.New("connection refused")
+		}
+		return "conn#1", nil
+	})
+	for i := 1; i <= 4; i++ {
+		v, err := conn.Get()
+		fmt.Printf("  Get %d: value=%q err=%v (fn attempts so far: %d)\n", i, v, err, attempts)
+	}
+
+	// ── LazyGroup[K, V] ───────────────────────────────────────────────────
+	fmt.Println("\n── LazyGroup[K, V] (per-key lazy init) ──")
+	var pools LazyGroup[string, string]
+	var inits sync.Map // host → *atomic.Int32
+	dial := func(host string) func() string {
+		return func() string {
+			n, _ := inits.LoadOrStore(host, new(atomic.Int32))
+			n.(*atomic.Int32).Add(1)
+			time.Sleep(10 * time.Millisecond) // simulate dialing
+			return "pool(" + host + ")"
+		}
+	}
+	var groupWg sync.WaitGroup
+	groupStart := time.Now()
+	for i := 0; i < 20; i++ {
+		host := []string{"db-1", "db-2"}[i%2]
+		groupWg.Add(1)
+		go func() {
+			defer groupWg.Done()
+			pools.Get(host, dial(host))
+		}()
+	}
+	groupWg.Wait()
+	for _, host := range []string{"db-1", "db-2"} {
+		n, _ := inits.Load(host)
+		fmt.Printf("  %s: init ran %d time(s) for 10 concurrent Gets → %s\n",
+			host, n.(*atomic.Int32).Load(), pools.Get(host, dial(host)))
+	}
+	fmt.Printf("  both keys initialized in parallel (< 20ms total): %v\n", time.Since(groupStart) < 20*time.Millisecond)
+
 	// ── sync.Pool — reuse temporary objects to reduce GC pressure ─────────
 	// sync.Pool holds objects that can be reused.
 	// When GC runs, it may clear the pool.
@@ -110,9 +411,86 @@ func main() {
 
 	consumerWg.Wait()
 
+	// ── BoundedQueue[T] ───────────────────────────────────────────────────
+	fmt.Println("\n── BoundedQueue[T] (fast producer, slow consumer) ──")
+	q := NewBoundedQueue[int](3)
+	ctx := context.Background()
+	var maxLen atomic.Int32
+	var qwg sync.WaitGroup
+	qwg.Add(1)
+	go func() { // producer: as fast as Put allows
+		defer qwg.Done()
+		for i := 1; i <= 8; i++ {
+			q.Put(ctx, i)
+			if n := int32(q.Len()); n > maxLen.Load() {
+				maxLen.Store(n)
+			}
+		}
+	}()
+	var taken []int
+	for i := 0; i < 8; i++ {
+		time.Sleep(2 * time.Millisecond) // slow consumer
+		v, _ := q.Take(ctx)
+		taken = append(taken, v)
+	}
+	qwg.Wait()
+	fmt.Printf("  taken %v, max len seen %d (cap %d)\n", taken, maxLen.Load(), q.Cap())
+
+	q.Put(ctx, 10)
+	q.Put(ctx, 20)
+	head, ok := q.Peek()
+	fmt.Printf("  Peek=%d ok=%v, Len still %d\n", head, ok, q.Len())
+	q.Put(ctx, 30) // now full
+
+	tctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	start := time.Now()
+	err := q.Put(tctx, 40)
+	cancel()
+	fmt.Printf("  Put on full queue: err=%v after ~%dms\n", err, time.Since(start).Milliseconds()/10*10)
+
+	for q.Len() > 0 {
+		q.Take(ctx)
+	}
+	cctx, cancelTake := context.WithCancel(ctx)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancelTake()
+	}()
+	_, err = q.Take(cctx)
+	fmt.Printf("  Take on empty queue, cancelled while blocked: err=%v\n", err)
+
+	// ── Barrier ───────────────────────────────────────────────────────────
+	fmt.Println("\n── Barrier (4 workers, 2 rounds) ──")
+	const workers = 4
+	barrier := NewBarrier(workers)
+	var arrived [2]atomic.Int32
+	var early atomic.Int32 // passed the barrier before everyone arrived
+	var bwg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		bwg.Add(1)
+		go func(id int) {
+			defer bwg.Done()
+			for round := 0; round < 2; round++ {
+				time.Sleep(time.Duration(id*3) * time.Millisecond) // staggered arrival
+				arrived[round].Add(1)
+				barrier.Wait()
+				if arrived[round].Load() != workers {
+					early.Add(1)
+				}
+			}
+		}(w)
+	}
+	bwg.Wait()
+	fmt.Printf("  round 1 arrivals=%d, round 2 arrivals=%d, released early=%d\n",
+		arrived[0].Load(), arrived[1].Load(), early.Load())
+
 	fmt.Println("\n─── SUMMARY ────────────────────────────────")
 	fmt.Println("  sync.Once: run init exactly once, thread-safe singleton")
+	fmt.Println("  Lazy[T]: Once + cached value; LazyErr[T]: retries until success")
+	fmt.Println("  LazyGroup[K,V]: a Lazy per key; keys initialize independently")
 	fmt.Println("  sync.Pool: reuse objects, reduce GC pressure (cleared on GC)")
 	fmt.Println("  sync.Cond: wait for condition, Broadcast (all) or Signal (one)")
 	fmt.Println("  Always loop-check condition with Wait (spurious wakeups)")
+	fmt.Println("  BoundedQueue[T]: mutex + 2 Conds, ctx via context.AfterFunc, Peek")
+	fmt.Println("  Barrier: Cond + generation counter, reusable across rounds")
 }
@@ -6,8 +6,13 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // ── STAGE FUNCTIONS ───────────────────────────────────────────────────────────
@@ -92,6 +97,101 @@ func merge(done <-chan struct{}, channels ...<-chan int) <-chan int {
 	return out
 }
 
+// ── PIPELINE WITH ERRORS — context + first error wins ────────────────────────
+// The stages above can't fail. Real stages (parse, enrich via HTTP, store)
+// can, and then the whole pipeline should stop — not just the stage that
+// broke. PipelineErr ties every stage to one derived context:
+//
+//   source ─▶ stage 1 ─▶ stage 2 ─▶ ... ─▶ out
+//      ▲         ▲          │ error
+//      └─────────┴── cancel ┘ (shared ctx) → upstream stops, out closes
+//
+// The FIRST error is recorded and cancels the context; later errors (often
+// just context.Canceled echoes) are dropped. Run returns the output channel
+// and a wait func: drain out, then call wait for the error — the same shape
+// as errgroup.Wait. Stages receive the shared ctx so in-flight work (an HTTP
+// call, a DB query) is cancelled too.
+//
+// The consumer must keep reading out until it closes (or cancel the parent
+// ctx); a stage blocked on send otherwise never exits.
+
+type PipelineErr[T any] struct {
+	stages []func(context.Context, T) (T, error)
+}
+
+func NewPipelineErr[T any](stages ...func(context.Context, T) (T, error)) *PipelineErr[T] {
+	return &PipelineErr[T]{stages: stages}
+}
+
+func (p *PipelineErr[T]) Run(parent context.Context, inputs []T) (<-chan T, func() error) {
+	ctx, cancel := context.WithCancel(parent)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	// Source: like generate, but stops on ctx instead of a done channel.
+	src := make(chan T)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(src)
+		for _, v := range inputs {
+			select {
+			case src <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	in := (<-chan T)(src)
+	for i, stage := range p.stages {
+		out := make(chan T)
+		wg.Add(1)
+		go func(i int, stage func(context.Context, T) (T, error), in <-chan T, out chan<- T) {
+			defer wg.Done()
+			defer close(out)
+			for v := range in {
+				r, err := stage(ctx, v)
+				if err != nil {
+					fail(fmt.Errorf("stage %d: %w", i, err))
+					return
+				}
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(i, stage, in, out)
+		in = out
+	}
+
+	var result error
+	waitDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		if firstErr == nil {
+			firstErr = ctx.Err() // parent cancelled with no stage error
+		}
+		result = firstErr
+		cancel()
+		close(waitDone)
+	}()
+	return in, func() error {
+		<-waitDone
+		return result
+	}
+}
+
 func main() {
 	fmt.Println("════════════════════════════════════════")
 	fmt.Println("  Topic: Pipeline Pattern")
@@ -151,6 +251,56 @@ func main() {
 	}
 	fmt.Println("  Pipeline cancelled after 3 values")
 
+	// ── PipelineErr ────────────────────────────────────────────────────
+	fmt.Println("\n── PipelineErr (happy path) ──")
+	double := func(_ context.Context, n int) (int, error) { return n * 2, nil }
+	inc := func(_ context.Context, n int) (int, error) { return n + 1, nil }
+	out, wait := NewPipelineErr(double, inc).Run(context.Background(), []int{1, 2, 3, 4})
+	fmt.Print("  Results: ")
+	for v := range out {
+		fmt.Printf("%d ", v)
+	}
+	fmt.Printf("\n  wait() = %v\n", wait())
+
+	fmt.Println("\n── PipelineErr (middle stage fails) ──")
+	before := runtime.NumGoroutine()
+	errBadRecord := errors.New("bad record")
+	var firstSeen atomic.Int32
+	countFirst := func(_ context.Context, n int) (int, error) {
+		firstSeen.Add(1)
+		return n, nil
+	}
+	validate := func(_ context.Context, n int) (int, error) {
+		if n == 5 {
+			return 0, fmt.Errorf("value %d: %w", n, errBadRecord)
+		}
+		return n, nil
+	}
+	slowStore := func(ctx context.Context, n int) (int, error) {
+		select {
+		case <-time.After(time.Millisecond): // simulated write
+			return n, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i + 1
+	}
+	out, wait = NewPipelineErr(countFirst, validate, slowStore).Run(context.Background(), inputs)
+	received := 0
+	for range out {
+		received++
+	}
+	err := wait()
+	fmt.Printf("  received %d of 1000; err=%v\n", received, err)
+	fmt.Printf("  errors.Is(err, errBadRecord): %v\n", errors.Is(err, errBadRecord))
+	fmt.Printf("  upstream stage saw %d of 1000 inputs (stopped early: %v)\n",
+		firstSeen.Load(), firstSeen.Load() < 1000)
+	time.Sleep(10 * time.Millisecond)
+	fmt.Printf("  goroutines before=%d after=%d (no leaked stages)\n", before, runtime.NumGoroutine())
+
 	fmt.Println("\n─── SUMMARY ────────────────────────────────")
 	fmt.Println("  Pipeline: stages connected by channels")
 	fmt.Println("  Each stage: goroutine reading input, writing output channel")
@@ -158,4 +308,5 @@ func main() {
 	fmt.Println("  Fan-out: one source → multiple parallel workers")
 	fmt.Println("  Fan-in: merge multiple channels → one (merge function)")
 	fmt.Println("  close(done) cancels everything — clean shutdown")
+	fmt.Println("  PipelineErr: shared ctx, first error cancels all stages, wait() returns it")
 }
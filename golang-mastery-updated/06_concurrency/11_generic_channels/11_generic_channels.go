@@ -0,0 +1,467 @@
+// FILE: 06_concurrency/11_generic_channels/11_generic_channels.go
+// TOPIC: Generic Channel Utilities — drop-oldest buffers, bridges, stream helpers, pub/sub
+//
+// Run: go run 06_concurrency/11_generic_channels/11_generic_channels.go
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ── DropChannel — "latest wins" under backpressure ────────────────────────────
+// A plain buffered channel blocks the sender when full. For metrics and logs
+// that is the wrong trade-off: a slow consumer would stall the hot path.
+// DropChannel never blocks on Send — when the buffer is full it evicts the
+// OLDEST item to make room, and counts the loss so it can be reported.
+//
+// Senders are serialized by a mutex so "evict one, insert one" is a single
+// step: two concurrent senders can't both evict for the same free slot.
+// Receivers need no lock — a receive can only ever create space.
+
+type DropChannel[T any] struct {
+	mu      sync.Mutex
+	ch      chan T
+	dropped atomic.Int64
+}
+
+func NewDropChannel[T any](capacity int) *DropChannel[T] {
+	if capacity < 1 {
+		capacity = 1 // an unbuffered channel can't hold anything to drop
+	}
+	return &DropChannel[T]{ch: make(chan T, capacity)}
+}
+
+// Send enqueues v without blocking, dropping the oldest item if full.
+func (d *DropChannel[T]) Send(v T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for {
+		select {
+		case d.ch <- v:
+			return
+		default:
+		}
+		select {
+		case <-d.ch: // evict oldest
+			d.dropped.Add(1)
+		default: // a receiver freed a slot in between — retry the send
+		}
+	}
+}
+
+// Receive returns the oldest buffered item, or false if the buffer is empty.
+func (d *DropChannel[T]) Receive() (T, bool) {
+	select {
+	case v := <-d.ch:
+		return v, true
+	default:
+		var zero T
+		return zero, false
+	}
+}
+
+func (d *DropChannel[T]) Len() int       { return len(d.ch) }
+func (d *DropChannel[T]) Dropped() int64 { return d.dropped.Load() }
+
+// ── SliceToChan / ChanToSlice — bridging collections and pipelines ────────────
+// SliceToChan is a generator: it feeds a pipeline from an in-memory slice.
+// ChanToSlice is the sink: it drains a pipeline back into a slice.
+// Together they let the slice helpers and channel stages compose freely.
+
+// SliceToChan emits each element of s in order, then closes the channel.
+func SliceToChan[T any](s []T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, v := range s {
+			out <- v
+		}
+	}()
+	return out
+}
+
+// ChanToSlice drains ch until it is closed. Always returns a non-nil slice.
+func ChanToSlice[T any](ch <-chan T) []T {
+	result := []T{}
+	for v := range ch {
+		result = append(result, v)
+	}
+	return result
+}
+
+// ── FlattenChan — batches in, elements out ───────────────────────────────────
+// Some stages naturally produce batches (a DB page, a decoded message group);
+// the next stage wants one item at a time. FlattenChan unrolls each batch in
+// order. An empty batch emits nothing; the output closes after the input does.
+
+func FlattenChan[T any](in <-chan []T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for batch := range in {
+			for _, v := range batch {
+				out <- v
+			}
+		}
+	}()
+	return out
+}
+
+// ── DedupChan / DedupChanWindow — drop repeated values ───────────────────────
+// At-least-once delivery (retries, redelivered queue messages, duplicate
+// EventBus publishes) means the same value can arrive twice. DedupChan
+// forwards only the FIRST occurrence of each value.
+//
+// Its seen-set grows with every distinct value, which is fine for a bounded
+// stream but a leak on an endless one. DedupChanWindow remembers only the
+// last `size` distinct values it forwarded (FIFO eviction), so memory is
+// bounded; a value that recurs after falling out of the window passes again.
+//
+// Both close their output once the input is closed and drained.
+
+func DedupChan[T comparable](in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		seen := make(map[T]struct{})
+		for v := range in {
+			if _, dup := seen[v]; dup {
+				continue
+			}
+			seen[v] = struct{}{}
+			out <- v
+		}
+	}()
+	return out
+}
+
+func DedupChanWindow[T comparable](in <-chan T, size int) <-chan T {
+	if size < 1 {
+		panic("DedupChanWindow: size must be >= 1")
+	}
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		seen := make(map[T]struct{}, size)
+		window := make([]T, size) // ring of forwarded values, oldest at next
+		next := 0
+		for v := range in {
+			if _, dup := seen[v]; dup {
+				continue
+			}
+			if len(seen) == size {
+				delete(seen, window[next]) // evict the oldest
+			}
+			seen[v] = struct{}{}
+			window[next] = v
+			next = (next + 1) % size
+			out <- v
+		}
+	}()
+	return out
+}
+
+// ── ChunkStream — elements in, batches out ───────────────────────────────────
+// The reverse of FlattenChan, and the channel version of Chunk: group items
+// into []T of up to size for bulk inserts or batched API calls. When the
+// input closes, a partial final batch is flushed, never dropped.
+//
+// On a slow input a half-full batch could sit for minutes. ChunkStreamWait
+// adds a deadline: a batch is flushed when it reaches size OR when maxWait
+// has passed since its FIRST item arrived, whichever comes first. The timer
+// only runs while a batch is pending, so an idle input emits nothing.
+//
+// Every emitted slice is freshly allocated; consumers may keep it.
+
+func ChunkStream[T any](in <-chan T, size int) <-chan []T {
+	return ChunkStreamWait(in, size, 0)
+}
+
+// ChunkStreamWait is ChunkStream with a max wait per batch; maxWait <= 0
+// disables the deadline. Panics if size < 1.
+func ChunkStreamWait[T any](in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	if size < 1 {
+		panic("ChunkStream: size must be >= 1")
+	}
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var (
+			batch    []T
+			timer    *time.Timer
+			deadline <-chan time.Time // nil (blocks forever) while no batch is pending
+		)
+		flush := func() {
+			if timer != nil {
+				timer.Stop()
+				deadline = nil
+			}
+			out <- batch
+			batch = nil
+		}
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if len(batch) > 0 {
+						flush()
+					}
+					return
+				}
+				if len(batch) == 0 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					deadline = timer.C
+				}
+				batch = append(batch, v)
+				if len(batch) == size {
+					flush()
+				}
+			case <-deadline:
+				deadline = nil
+				flush()
+			}
+		}
+	}()
+	return out
+}
+
+// ── Topic[T] — broadcast to channels instead of callbacks ────────────────────
+// The EventBus in 10_advanced_patterns/03 calls handlers; a Topic hands each
+// subscriber its own buffered channel, so consumers just `range` over it and
+// run at their own pace on their own goroutine.
+//
+// Publish must never block on a slow subscriber, so each send is a select
+// with default: when a subscriber's buffer is full, THAT subscriber misses
+// the value (drop-newest, unlike DropChannel's drop-oldest) and the drop is
+// counted. Everyone else still gets it.
+//
+// Publish holds the read lock, Subscribe and Close the write lock — so
+// publishers run in parallel, and Close can never close a channel while a
+// Publish is sending on it. Publish after Close is a no-op.
+
+type Topic[T any] struct {
+	mu      sync.RWMutex
+	subs    []chan T
+	buffer  int
+	closed  bool
+	dropped atomic.Int64
+}
+
+func NewTopic[T any](buffer int) *Topic[T] {
+	if buffer < 1 {
+		buffer = 1 // unbuffered would drop unless a receiver is already waiting
+	}
+	return &Topic[T]{buffer: buffer}
+}
+
+// Subscribe returns a new channel that receives every value published from
+// now on. On a closed Topic the channel is returned already closed.
+func (t *Topic[T]) Subscribe() <-chan T {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ch := make(chan T, t.buffer)
+	if t.closed {
+		close(ch)
+		return ch
+	}
+	t.subs = append(t.subs, ch)
+	return ch
+}
+
+// Publish offers v to every subscriber without blocking.
+func (t *Topic[T]) Publish(v T) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.closed {
+		return
+	}
+	for _, ch := range t.subs {
+		select {
+		case ch <- v:
+		default: // this subscriber is behind — skip it, not the others
+			t.dropped.Add(1)
+		}
+	}
+}
+
+// Close closes every subscriber channel; their range loops end once drained.
+func (t *Topic[T]) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	t.closed = true
+	for _, ch := range t.subs {
+		close(ch)
+	}
+	t.subs = nil
+}
+
+// Dropped counts (subscriber, value) pairs lost to full buffers.
+func (t *Topic[T]) Dropped() int64 { return t.dropped.Load() }
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Generic Channel Utilities")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── DropChannel ───────────────────────────────────────────────────────
+	fmt.Println("\n── DropChannel (drop-oldest) ──")
+	dc := NewDropChannel[int](3)
+	for i := 1; i <= 3; i++ {
+		dc.Send(i)
+	}
+	fmt.Printf("  filled to capacity: len=%d dropped=%d\n", dc.Len(), dc.Dropped())
+	dc.Send(4) // full → evicts 1
+	fmt.Printf("  Send(4) on full:    len=%d dropped=%d\n", dc.Len(), dc.Dropped())
+	dc.Send(5) // full → evicts 2
+	fmt.Printf("  Send(5) on full:    len=%d dropped=%d\n", dc.Len(), dc.Dropped())
+
+	var kept []int
+	for v, ok := dc.Receive(); ok; v, ok = dc.Receive() {
+		kept = append(kept, v)
+	}
+	fmt.Printf("  drained: %v (oldest two were dropped)\n", kept)
+
+	// Concurrent senders never block and never lose count:
+	metrics := NewDropChannel[int](10)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				metrics.Send(i)
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("  8×1000 concurrent sends: buffered=%d + dropped=%d = %d\n",
+		metrics.Len(), metrics.Dropped(), int64(metrics.Len())+metrics.Dropped())
+
+	// ── SliceToChan / ChanToSlice ─────────────────────────────────────────
+	fmt.Println("\n── SliceToChan / ChanToSlice ──")
+	words := []string{"alpha", "beta", "gamma"}
+	roundTrip := ChanToSlice(SliceToChan(words))
+	fmt.Printf("  round trip: %q (len %d)\n", roundTrip, len(roundTrip))
+
+	// Feed a stage in between:
+	scaled := make(chan int)
+	go func() {
+		defer close(scaled)
+		for n := range SliceToChan([]int{1, 2, 3}) {
+			scaled <- n * 10
+		}
+	}()
+	fmt.Printf("  slice → ×10 stage → slice: %v\n", ChanToSlice(scaled))
+
+	closed := make(chan int)
+	close(closed)
+	empty := ChanToSlice(closed)
+	fmt.Printf("  closed empty channel: %v, nil=%v\n", empty, empty == nil)
+
+	// ── FlattenChan ───────────────────────────────────────────────────────
+	fmt.Println("\n── FlattenChan ──")
+	pages := make(chan []string)
+	go func() {
+		defer close(pages)
+		pages <- []string{"row1", "row2"}
+		pages <- []string{} // empty page contributes nothing
+		pages <- []string{"row3"}
+	}()
+	flat := FlattenChan(pages)
+	for row := range flat {
+		fmt.Printf("  got %s\n", row)
+	}
+	_, open := <-flat
+	fmt.Printf("  output closed after input: %v\n", !open)
+
+	// ── DedupChan / DedupChanWindow ───────────────────────────────────────
+	fmt.Println("\n── DedupChan / DedupChanWindow ──")
+	events := []string{"a", "b", "a", "c", "b", "d", "a", "e"}
+	fmt.Printf("  input:             %v\n", events)
+	fmt.Printf("  DedupChan:         %v\n", ChanToSlice(DedupChan(SliceToChan(events))))
+	fmt.Printf("  DedupChanWindow 2: %v (a fell out of the window, so it passes again)\n",
+		ChanToSlice(DedupChanWindow(SliceToChan(events), 2)))
+	dedupOut := DedupChan(SliceToChan([]int{}))
+	_, open = <-dedupOut
+	fmt.Printf("  output closed after empty input: %v\n", !open)
+
+	// ── ChunkStream / ChunkStreamWait ─────────────────────────────────────
+	fmt.Println("\n── ChunkStream / ChunkStreamWait ──")
+	fmt.Printf("  7 items, size 3: %v (partial batch flushed on close)\n",
+		ChanToSlice(ChunkStream(SliceToChan([]int{1, 2, 3, 4, 5, 6, 7}), 3)))
+	fmt.Printf("  6 items, size 3: %v\n", ChanToSlice(ChunkStream(SliceToChan([]int{1, 2, 3, 4, 5, 6}), 3)))
+
+	slowIn := make(chan string)
+	go func() {
+		defer close(slowIn)
+		slowIn <- "a"
+		slowIn <- "b"
+		time.Sleep(60 * time.Millisecond) // a lull longer than maxWait
+		slowIn <- "c"
+	}()
+	start := time.Now()
+	for b := range ChunkStreamWait(slowIn, 100, 20*time.Millisecond) {
+		fmt.Printf("  size 100, maxWait 20ms: batch %v at ~%dms\n", b, time.Since(start).Milliseconds()/10*10)
+	}
+
+	// ── Topic ─────────────────────────────────────────────────────────────
+	fmt.Println("\n── Topic[T] (channel pub/sub) ──")
+	topic := NewTopic[string](8)
+	received := make([][]string, 2)
+	for i := range received {
+		sub := topic.Subscribe()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for msg := range sub { // ends when the Topic is closed
+				received[i] = append(received[i], msg)
+			}
+		}(i)
+	}
+	for _, msg := range []string{"deploy started", "build ok", "deploy done"} {
+		topic.Publish(msg)
+	}
+	topic.Close()
+	wg.Wait()
+	fmt.Printf("  subscriber 1: %q\n  subscriber 2: %q\n", received[0], received[1])
+	_, open = <-topic.Subscribe()
+	fmt.Printf("  Subscribe after Close → closed channel: %v\n", !open)
+
+	ticksTopic := NewTopic[int](4)
+	stalled := ticksTopic.Subscribe() // never read
+	live := ticksTopic.Subscribe()
+	var liveCount atomic.Int64
+	liveDone := make(chan struct{})
+	go func() {
+		defer close(liveDone)
+		for range live {
+			liveCount.Add(1)
+		}
+	}()
+	start = time.Now()
+	for i := 0; i < 1000; i++ {
+		ticksTopic.Publish(i)
+	}
+	elapsed := time.Since(start)
+	ticksTopic.Close()
+	<-liveDone
+	fmt.Printf("  1000 publishes with a stalled subscriber: returned in <100ms: %v\n", elapsed < 100*time.Millisecond)
+	fmt.Printf("  stalled kept %d (its buffer); live got %d; dropped=%d; every value accounted: %v\n",
+		len(stalled), liveCount.Load(), ticksTopic.Dropped(),
+		int64(len(stalled))+liveCount.Load()+ticksTopic.Dropped() == 2000)
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  DropChannel: non-blocking Send, evicts oldest when full, counts drops")
+	fmt.Println("  SliceToChan / ChanToSlice: generator and sink for pipelines")
+	fmt.Println("  FlattenChan: unrolls []T batches into single elements, order kept")
+	fmt.Println("  DedupChan: first occurrence only; Window variant bounds memory to N values")
+	fmt.Println("  ChunkStream: batches of up to size, partial flush on close; Wait adds a deadline")
+	fmt.Println("  Topic[T]: buffered chan per subscriber, non-blocking Publish, counts drops")
+}
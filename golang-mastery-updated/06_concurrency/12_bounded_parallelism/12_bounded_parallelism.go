@@ -0,0 +1,480 @@
+// FILE: 06_concurrency/12_bounded_parallelism/12_bounded_parallelism.go
+// TOPIC: Bounded Parallelism — generic fan-out helpers with limits, errors, cancellation
+//
+// Run: go run 06_concurrency/12_bounded_parallelism/12_bounded_parallelism.go
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ── ForEachConcurrent — side effects over a slice, N at a time ────────────────
+// The worker pool from 08_worker_pool, packaged as one generic call:
+//   - exactly `workers` goroutines pull items from a shared jobs channel
+//   - the first error cancels a derived context, so in-flight fn calls can
+//     bail out and no new items are started
+//   - an already-cancelled parent context means nothing runs at all
+//
+// The returned error is the FIRST failure, or the parent's ctx.Err() if the
+// caller cancelled before all items were processed.
+
+func ForEachConcurrent[T any](ctx context.Context, items []T, workers int, fn func(context.Context, T) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		firstErr error
+		errOnce  sync.Once
+		wg       sync.WaitGroup
+	)
+	jobs := make(chan T)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				if ctx.Err() != nil {
+					continue // drain remaining jobs without running them
+				}
+				if err := fn(ctx, item); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, item := range items {
+		select {
+		case jobs <- item:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err() // non-nil only if the parent was cancelled mid-way
+}
+
+// ── OrderedParallel — parallel transform, input-order output ─────────────────
+// Fanning out to N workers makes results arrive in COMPLETION order. When the
+// consumer needs input order, tag each item with a sequence number and put a
+// reorder buffer in front of the output:
+//
+//   in ──▶ [seq] ──▶ N workers ──▶ reorder buffer ──▶ out (0, 1, 2, ...)
+//
+// The buffer holds results that finished early until every earlier sequence
+// number has been emitted. One slow item therefore delays everything behind
+// it (head-of-line blocking) — the price of the ordering guarantee.
+
+type sequenced[T any] struct {
+	seq int
+	val T
+}
+
+func OrderedParallel[T, R any](in <-chan T, workers int, fn func(T) R) <-chan R {
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan sequenced[T])
+	results := make(chan sequenced[R])
+	out := make(chan R)
+
+	// Stage 1: number the inputs
+	go func() {
+		defer close(jobs)
+		seq := 0
+		for v := range in {
+			jobs <- sequenced[T]{seq, v}
+			seq++
+		}
+	}()
+
+	// Stage 2: transform concurrently
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results <- sequenced[R]{j.seq, fn(j.val)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Stage 3: reorder
+	go func() {
+		defer close(out)
+		pending := make(map[int]R)
+		next := 0
+		for r := range results {
+			pending[r.seq] = r.val
+			for v, ok := pending[next]; ok; v, ok = pending[next] {
+				out <- v
+				delete(pending, next)
+				next++
+			}
+		}
+	}()
+	return out
+}
+
+// ── TryMap — process everything, report every failure ────────────────────────
+// ForEachConcurrent stops at the first error. Batch jobs ("resize all these
+// images", "ping every host") usually want the opposite: run every item,
+// then report which ones failed.
+//
+// Results and errors are INDEX-ALIGNED with items: results[i] and errs[i]
+// belong to items[i], errs[i] is nil on success (and results[i] is whatever
+// fn returned — typically the zero value — on failure). Each worker writes
+// only its own indices, so the slices need no lock.
+
+func TryMap[T, R any](items []T, workers int, fn func(T) (R, error)) ([]R, []error) {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(items)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i], errs[i] = fn(items[i])
+			}
+		}()
+	}
+	for i := range items {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return results, errs
+}
+
+// ── GatherN — run N tasks, at most K at a time, all-or-nothing ────────────────
+// "Fetch these 20 pages, 4 at a time, give me all of them or the first
+// failure." Instead of a fixed pool, each task gets its own goroutine, gated
+// by the buffered-channel semaphore from 02_channels_basics:
+//
+//   sem <- struct{}{}   acquire: blocks while K tasks are running
+//   <-sem               release: lets the next one start
+//
+// The launcher acquires BEFORE starting each goroutine, so at most K exist at
+// once, and it stops launching as soon as ctx is done. The first error
+// cancels ctx: tasks not yet started never run, running ones see ctx.Done().
+//
+// Results are index-aligned with tasks; on failure the slice is nil and the
+// error is the first one (or the parent's ctx.Err() if the caller cancelled).
+
+func GatherN[T any](ctx context.Context, maxConcurrent int, tasks []func(context.Context) (T, error)) ([]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		results  = make([]T, len(tasks))
+		firstErr error
+		errOnce  sync.Once
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, maxConcurrent)
+
+launch:
+	for i, task := range tasks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break launch
+		}
+		if ctx.Err() != nil { // select picks randomly when both are ready
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			v, err := task(ctx)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = v // each goroutine owns one index — no lock
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err // parent cancelled mid-way
+	}
+	return results, nil
+}
+
+// ── CollectResults — don't lose errors from a worker pool ────────────────────
+// A results channel of plain values forces workers to log-and-drop failures.
+// Sending Result[T] (same shape as 09_generics/07_generics_patterns) keeps
+// the error next to the job, and CollectResults splits them at the end.
+//
+// It drains until the channel is CLOSED, so the producer side must close it
+// (typically wg.Wait(); close(results)). Within each bucket the order is
+// the order results were received — completion order for a pool.
+
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+func OK[T any](v T) Result[T]      { return Result[T]{value: v} }
+func Err[T any](e error) Result[T] { return Result[T]{err: e} }
+
+func (r Result[T]) IsOK() bool   { return r.err == nil }
+func (r Result[T]) Value() T     { return r.value }
+func (r Result[T]) Error() error { return r.err }
+
+// CollectResults drains results and separates successes from failures.
+func CollectResults[T any](results <-chan Result[T]) (values []T, errs []error) {
+	for r := range results {
+		if r.IsOK() {
+			values = append(values, r.Value())
+		} else {
+			errs = append(errs, r.Error())
+		}
+	}
+	return values, errs
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Bounded Parallelism")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── ForEachConcurrent: worker limit ───────────────────────────────────
+	fmt.Println("\n── ForEachConcurrent (limit 3) ──")
+	var running, peak atomic.Int32
+	items := make([]int, 12)
+	for i := range items {
+		items[i] = i
+	}
+	err := ForEachConcurrent(context.Background(), items, 3, func(ctx context.Context, n int) error {
+		cur := running.Add(1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	})
+	fmt.Printf("  12 items, err=%v, peak concurrency=%d (limit 3)\n", err, peak.Load())
+
+	// ── ForEachConcurrent: first error cancels the rest ───────────────────
+	fmt.Println("\n── ForEachConcurrent (cancel on error) ──")
+	var started atomic.Int32
+	errBad := errors.New("item 2 is corrupt")
+	err = ForEachConcurrent(context.Background(), items, 2, func(ctx context.Context, n int) error {
+		started.Add(1)
+		if n == 2 {
+			return errBad
+		}
+		select {
+		case <-time.After(20 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err() // cancelled by the failure — not reported (first error wins)
+		}
+	})
+	fmt.Printf("  err=%v, is errBad=%v, started %d of %d items\n",
+		err, errors.Is(err, errBad), started.Load(), len(items))
+
+	// ── ForEachConcurrent: already-cancelled input context ───────────────
+	fmt.Println("\n── ForEachConcurrent (pre-cancelled ctx) ──")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err = ForEachConcurrent(ctx, items, 4, func(context.Context, int) error { calls++; return nil })
+	fmt.Printf("  err=%v, fn calls=%d\n", err, calls)
+
+	// ── OrderedParallel ───────────────────────────────────────────────────
+	fmt.Println("\n── OrderedParallel (variable latency, 4 workers) ──")
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 1; i <= 8; i++ {
+			in <- i
+		}
+	}()
+	var finishOrder []int
+	var finishMu sync.Mutex
+	slowSquare := func(n int) int {
+		time.Sleep(time.Duration((9-n)*3) * time.Millisecond) // early items are SLOWER
+		finishMu.Lock()
+		finishOrder = append(finishOrder, n)
+		finishMu.Unlock()
+		return n * n
+	}
+	var emitted []int
+	for sq := range OrderedParallel(in, 4, slowSquare) {
+		emitted = append(emitted, sq)
+	}
+	fmt.Printf("  finish order: %v\n", finishOrder)
+	fmt.Printf("  emitted:      %v (input order)\n", emitted)
+
+	// ── TryMap ────────────────────────────────────────────────────────────
+	fmt.Println("\n── TryMap (limit 3, all items run) ──")
+	hosts := []string{"db-1", "db-2", "cache", "queue", "search", "db-3", "auth"}
+	running.Store(0)
+	peak.Store(0)
+	latencies, pingErrs := TryMap(hosts, 3, func(h string) (time.Duration, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		time.Sleep(3 * time.Millisecond)
+		if h == "cache" || h == "auth" {
+			return 0, fmt.Errorf("%s: connection refused", h)
+		}
+		return time.Duration(len(h)) * time.Millisecond, nil
+	})
+	failed := 0
+	for i, h := range hosts {
+		if pingErrs[i] != nil {
+			failed++
+			fmt.Printf("  [%d] %-6s ✗ %v\n", i, h, pingErrs[i])
+			continue
+		}
+		fmt.Printf("  [%d] %-6s ✓ %v\n", i, h, latencies[i])
+	}
+	fmt.Printf("  %d/%d failed, peak concurrency=%d (limit 3)\n", failed, len(hosts), peak.Load())
+
+	// ── GatherN ───────────────────────────────────────────────────────────
+	fmt.Println("\n── GatherN (limit 3) ──")
+	pageTasks := make([]func(context.Context) (string, error), 8)
+	for i := range pageTasks {
+		pageTasks[i] = func(ctx context.Context) (string, error) {
+			time.Sleep(time.Duration(8-i) * 2 * time.Millisecond) // later pages finish FIRST
+			return fmt.Sprintf("page-%d", i), nil
+		}
+	}
+	pages, err := GatherN(context.Background(), 3, pageTasks)
+	fmt.Printf("  err=%v, results index-aligned: %q\n", err, pages)
+
+	var launched, cancelled atomic.Int32
+	errQuota := errors.New("task 1: quota exceeded")
+	failTasks := make([]func(context.Context) (int, error), 10)
+	for i := range failTasks {
+		failTasks[i] = func(ctx context.Context) (int, error) {
+			launched.Add(1)
+			if i == 1 {
+				return 0, errQuota
+			}
+			select {
+			case <-time.After(50 * time.Millisecond):
+				return i, nil
+			case <-ctx.Done():
+				cancelled.Add(1)
+				return 0, ctx.Err()
+			}
+		}
+	}
+	nums, err := GatherN(context.Background(), 2, failTasks)
+	fmt.Printf("  fail fast: results=%v err=%v (is errQuota=%v)\n", nums, err, errors.Is(err, errQuota))
+	fmt.Printf("  launched %d of %d tasks, %d in-flight task(s) saw ctx.Done()\n",
+		launched.Load(), len(failTasks), cancelled.Load())
+
+	// ── CollectResults ────────────────────────────────────────────────────
+	fmt.Println("\n── CollectResults (worker pool of Result[T]) ──")
+	mixed := make(chan Result[int], 6)
+	mixed <- OK(1)
+	mixed <- Err[int](errors.New("job 2: timeout"))
+	mixed <- OK(3)
+	mixed <- OK(4)
+	mixed <- Err[int](errors.New("job 5: bad input"))
+	mixed <- OK(6)
+	close(mixed)
+	vals, errs := CollectResults(mixed)
+	fmt.Printf("  %d ok %v, %d failed %v\n", len(vals), vals, len(errs), errs)
+
+	// Real pool: 3 workers, every 4th job fails.
+	jobs := make(chan int)
+	results := make(chan Result[string])
+	var wg sync.WaitGroup
+	for w := 0; w < 3; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if j%4 == 0 {
+					results <- Err[string](fmt.Errorf("job %d failed", j))
+					continue
+				}
+				results <- OK(fmt.Sprintf("job-%d", j))
+			}
+		}()
+	}
+	go func() {
+		for j := 1; j <= 10; j++ {
+			jobs <- j
+		}
+		close(jobs)
+	}()
+	go func() {
+		wg.Wait()
+		close(results) // lets CollectResults return
+	}()
+	okVals, failures := CollectResults(results)
+	fmt.Printf("  pool: %d ok, %d failed %v\n", len(okVals), len(failures), failures)
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  ForEachConcurrent: N workers, first error cancels, respects parent ctx")
+	fmt.Println("  OrderedParallel: N workers + sequence numbers + reorder buffer")
+	fmt.Println("  TryMap: run all items, index-aligned results and errors")
+	fmt.Println("  GatherN: chan semaphore caps concurrency; index-aligned; first error cancels")
+	fmt.Println("  CollectResults: drain Result[T] channel into values and errors")
+}
@@ -0,0 +1,308 @@
+// FILE: 06_concurrency/13_concurrent_types/13_concurrent_types.go
+// TOPIC: Concurrency-Safe Generic Types — round-robin and other shared building blocks
+//
+// Run: go run 06_concurrency/13_concurrent_types/13_concurrent_types.go
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ── RoundRobin[T] — lock-free rotation over a fixed set ───────────────────────
+// Classic client-side load balancing: spread calls across N backends in turn.
+// The items never change after construction, so the only shared state is a
+// counter — one atomic Add per Next, no mutex.
+//
+// Next on an empty RoundRobin PANICS: there is no sensible backend to return,
+// and a zero-value URL would just fail later and further from the bug.
+
+type RoundRobin[T any] struct {
+	items []T
+	next  atomic.Uint64
+}
+
+func NewRoundRobin[T any](items []T) *RoundRobin[T] {
+	return &RoundRobin[T]{items: append([]T(nil), items...)} // copy: caller can't mutate our set
+}
+
+func (r *RoundRobin[T]) Next() T {
+	if len(r.items) == 0 {
+		panic("RoundRobin.Next: no items")
+	}
+	n := r.next.Add(1) - 1
+	return r.items[n%uint64(len(r.items))]
+}
+
+// ── ConcurrentBuilder — strings.Builder you can share ────────────────────────
+// strings.Builder has no lock; two goroutines writing at once is a data race.
+// Wrapping it in a mutex makes each Write atomic: pieces never interleave
+// mid-write. The ORDER of pieces from different goroutines is still
+// whatever order they won the lock in — nondeterministic.
+
+type ConcurrentBuilder struct {
+	mu sync.Mutex
+	sb strings.Builder
+}
+
+func (b *ConcurrentBuilder) WriteString(s string) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.WriteString(s)
+}
+
+func (b *ConcurrentBuilder) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.Write(p)
+}
+
+// String returns a snapshot of everything written so far.
+func (b *ConcurrentBuilder) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sb.String()
+}
+
+// ── SafeSlice[T] — collect results without a channel ─────────────────────────
+// Same idea for any element type. Append order across goroutines is
+// nondeterministic; Snapshot returns a COPY so callers can range over it
+// while other goroutines keep appending.
+
+type SafeSlice[T any] struct {
+	mu    sync.Mutex
+	items []T
+}
+
+func (s *SafeSlice[T]) Append(v ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = append(s.items, v...)
+}
+
+func (s *SafeSlice[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+func (s *SafeSlice[T]) Snapshot() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]T(nil), s.items...)
+}
+
+// ── Future[T] — start work now, collect the result later ─────────────────────
+// The raw-channel version of this (see 02_channels_basics) is a buffered
+// chan of size 1 — but a value can be received from it only ONCE. Future
+// stores the result and closes `done` instead: a closed channel is readable
+// by any number of waiters, forever, so every Await sees the same (T, error).
+//
+// Await honours ctx: giving up on the wait does NOT cancel fn, which keeps
+// running and stores its result for later Awaits. Pass fn its own ctx if it
+// should stop too.
+
+type Future[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+}
+
+// Async runs fn in a new goroutine and returns a Future for its result.
+func Async[T any](fn func() (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer close(f.done) // publishes val and err to every Await
+		f.val, f.err = fn()
+	}()
+	return f
+}
+
+// Await blocks until the result is ready or ctx is done.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// ── MetricsCounter — named counters for a tiny metrics registry ─────────────
+// Two kinds of shared state, two tools:
+//   - the SET of names changes rarely → map guarded by an RWMutex
+//   - each VALUE changes constantly   → *int64 bumped with atomic.AddInt64
+// Once a counter exists, Inc only takes the read lock to find its pointer,
+// so goroutines bumping different (or the same) counters never serialise on
+// the write lock. The write lock is taken once per new name.
+//
+// Snapshot loads every value atomically, but it is not a frozen instant
+// across counters: an Add already holding a pointer may land just before or
+// just after its counter is read. Fine for metrics; use one mutex for
+// everything if you need invariants between counters.
+//
+// The zero value is ready to use.
+
+type MetricsCounter struct {
+	mu       sync.RWMutex
+	counters map[string]*int64
+}
+
+// counter returns the pointer for name, creating it on first use.
+func (m *MetricsCounter) counter(name string) *int64 {
+	m.mu.RLock()
+	c, ok := m.counters[name]
+	m.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.counters[name]; ok { // another goroutine created it meanwhile
+		return c
+	}
+	if m.counters == nil {
+		m.counters = make(map[string]*int64)
+	}
+	c = new(int64)
+	m.counters[name] = c
+	return c
+}
+
+func (m *MetricsCounter) Inc(name string) { m.Add(name, 1) }
+
+func (m *MetricsCounter) Add(name string, n int64) {
+	atomic.AddInt64(m.counter(name), n)
+}
+
+// Snapshot returns a copy of every counter's current value.
+func (m *MetricsCounter) Snapshot() map[string]int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make(map[string]int64, len(m.counters))
+	for name, c := range m.counters {
+		out[name] = atomic.LoadInt64(c)
+	}
+	return out
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Concurrency-Safe Generic Types")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── RoundRobin ────────────────────────────────────────────────────────
+	fmt.Println("\n── RoundRobin[T] ──")
+	backends := NewRoundRobin([]string{"https://api-1.example.com", "https://api-2.example.com", "https://api-3.example.com"})
+	for i := 0; i < 5; i++ {
+		fmt.Printf("  call %d → %s\n", i+1, backends.Next())
+	}
+
+	// Under concurrency every backend gets an equal share:
+	rr := NewRoundRobin([]int{0, 1, 2})
+	var hits [3]atomic.Int64
+	var wg sync.WaitGroup
+	for g := 0; g < 10; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 300; i++ {
+				hits[rr.Next()].Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("  10×300 concurrent Next: hits=[%d %d %d]\n", hits[0].Load(), hits[1].Load(), hits[2].Load())
+
+	func() {
+		defer func() { fmt.Printf("  empty Next panics: %v\n", recover()) }()
+		NewRoundRobin[string](nil).Next()
+	}()
+
+	// ── ConcurrentBuilder / SafeSlice ─────────────────────────────────────
+	fmt.Println("\n── ConcurrentBuilder / SafeSlice[T] ──")
+	var cb ConcurrentBuilder
+	var results SafeSlice[int]
+	for g := 0; g < 50; g++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				cb.WriteString("x")
+				results.Append(id*100 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	fmt.Printf("  50 goroutines × 20 writes: builder len=%d, slice len=%d\n", len(cb.String()), results.Len())
+	snap := results.Snapshot()
+	results.Append(-1)
+	fmt.Printf("  snapshot is a copy: snap len=%d, live len=%d\n", len(snap), results.Len())
+
+	// ── Future / Async ────────────────────────────────────────────────────
+	fmt.Println("\n── Future[T] / Async ──")
+	var runs atomic.Int32
+	fut := Async(func() (string, error) {
+		runs.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return "report.pdf", nil
+	})
+	fmt.Println("  Async returned immediately; doing other work...")
+	v1, err1 := fut.Await(context.Background())
+	v2, err2 := fut.Await(context.Background())
+	fmt.Printf("  Await #1: %q %v  Await #2: %q %v  fn ran %d time(s)\n", v1, err1, v2, err2, runs.Load())
+
+	slow := Async(func() (int, error) {
+		time.Sleep(200 * time.Millisecond)
+		return 42, nil
+	})
+	actx, acancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	v, err := slow.Await(actx)
+	acancel()
+	fmt.Printf("  Await with 10ms timeout on 200ms work: %d %v (deadline=%v)\n",
+		v, err, errors.Is(err, context.DeadlineExceeded))
+	v, err = slow.Await(context.Background())
+	fmt.Printf("  work kept running; later Await: %d %v\n", v, err)
+
+	failing := Async(func() (int, error) { return 0, errors.New("upstream 503") })
+	_, err = failing.Await(context.Background())
+	fmt.Printf("  fn error is returned by Await: %v\n", err)
+
+	// ── MetricsCounter ────────────────────────────────────────────────────
+	fmt.Println("\n── MetricsCounter ──")
+	var metrics MetricsCounter
+	for g := 0; g < 20; g++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				metrics.Inc("requests")
+				if i%10 == 0 {
+					metrics.Inc("errors")
+				}
+			}
+			metrics.Add("bytes", int64(1024*(id+1)))
+		}(g)
+	}
+	wg.Wait()
+	totals := metrics.Snapshot()
+	fmt.Printf("  20 goroutines × 500 Inc: requests=%d (want 10000) errors=%d (want 1000)\n",
+		totals["requests"], totals["errors"])
+	fmt.Printf("  Add(bytes, 1KiB×(id+1)): bytes=%d (want %d)\n", totals["bytes"], 1024*20*21/2)
+	metrics.Inc("requests")
+	fmt.Printf("  snapshot is a copy: snap=%d, live=%d\n", totals["requests"], metrics.Snapshot()["requests"])
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  RoundRobin[T]: atomic counter modulo len; panics when empty")
+	fmt.Println("  ConcurrentBuilder / SafeSlice[T]: mutex-guarded, order not guaranteed")
+	fmt.Println("  Future[T]: closed done chan → any number of Awaits see one cached result")
+	fmt.Println("  MetricsCounter: RWMutex map of names, atomic.AddInt64 per value")
+}
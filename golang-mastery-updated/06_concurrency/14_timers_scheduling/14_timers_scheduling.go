@@ -0,0 +1,269 @@
+// FILE: 06_concurrency/14_timers_scheduling/14_timers_scheduling.go
+// TOPIC: Timers & Scheduling — context-aware sleep/tick, and a task scheduler
+//
+// Run: go run 06_concurrency/14_timers_scheduling/14_timers_scheduling.go
+
+package main
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ── SLEEP CONTEXT — time.Sleep you can cancel ────────────────────────────────
+// time.Sleep ignores cancellation: a shutdown waits out every sleeping retry
+// loop. The usual fix, select on time.After(d), LEAKS a timer until d
+// elapses (pre-Go 1.23) when ctx wins. Owning the timer and stopping it on
+// the way out releases it immediately.
+
+// SleepContext waits for d, or returns ctx.Err() as soon as ctx is done.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ── TICK CONTEXT — a ticker that cleans itself up ────────────────────────────
+// A time.Ticker runs until Stop is called; forgetting Stop leaks it for the
+// life of the program. TickContext ties the ticker to ctx: when ctx is done,
+// the ticker is stopped and the returned channel is CLOSED, so a plain
+// `for t := range TickContext(ctx, d)` loop ends by itself.
+//
+// Like time.Ticker, ticks are dropped (not queued) if the receiver is slow.
+
+func TickContext(ctx context.Context, d time.Duration) <-chan time.Time {
+	out := make(chan time.Time, 1)
+	go func() {
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case t := <-ticker.C:
+				select {
+				case out <- t:
+				default: // receiver is behind — drop, as time.Ticker does
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// ── SCHEDULER — min-heap of tasks + a single timer ────────────────────────────
+// One goroutine per delayed task (time.AfterFunc each) works, but gives you
+// no ordering, no central cancellation, and thousands of timers. Instead:
+//
+//   Schedule ──▶ min-heap ordered by runAt ──▶ Run loop
+//                                               │ pop everything due
+//                                               │ arm ONE timer for the next
+//                                               └ sleep until timer / new task / ctx
+//
+// A newly scheduled task may be earlier than the one the timer is armed for,
+// so Schedule pokes the loop through `wake` and the timer is re-armed.
+// Tasks whose runAt is already in the past run on the next loop iteration.
+// Equal runAt values run in Schedule order (seq tie-break).
+//
+// Tasks run ON the Run goroutine, one at a time — a slow task delays the
+// ones behind it. Hand long work off to a worker pool inside the task.
+
+type scheduledTask struct {
+	runAt time.Time
+	seq   uint64
+	fn    func()
+}
+
+// taskHeap implements heap.Interface; the earliest runAt is at index 0.
+type taskHeap []scheduledTask
+
+func (h taskHeap) Len() int { return len(h) }
+func (h taskHeap) Less(i, j int) bool {
+	if h[i].runAt.Equal(h[j].runAt) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].runAt.Before(h[j].runAt)
+}
+func (h taskHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *taskHeap) Push(x any)   { *h = append(*h, x.(scheduledTask)) }
+func (h *taskHeap) Pop() any {
+	old := *h
+	t := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return t
+}
+
+type Scheduler struct {
+	mu    sync.Mutex
+	tasks taskHeap
+	seq   uint64
+	wake  chan struct{} // buffered(1): "the heap changed, recompute the timer"
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{wake: make(chan struct{}, 1)}
+}
+
+// Schedule queues task to run at runAt. Safe to call from any goroutine,
+// including from inside a running task.
+func (s *Scheduler) Schedule(runAt time.Time, task func()) {
+	s.mu.Lock()
+	heap.Push(&s.tasks, scheduledTask{runAt: runAt, seq: s.seq, fn: task})
+	s.seq++
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default: // a wake-up is already pending
+	}
+}
+
+// Pending returns the number of tasks not yet run.
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tasks)
+}
+
+// next pops every due task and reports how long until the following one
+// (ok=false when the heap is empty).
+func (s *Scheduler) next(now time.Time) (due []func(), wait time.Duration, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.tasks) > 0 && !s.tasks[0].runAt.After(now) {
+		due = append(due, heap.Pop(&s.tasks).(scheduledTask).fn)
+	}
+	if len(s.tasks) > 0 {
+		return due, s.tasks[0].runAt.Sub(now), true
+	}
+	return due, 0, false
+}
+
+// Run executes tasks as they come due until ctx is cancelled. Tasks still in
+// the heap at that point are left there (see Pending).
+func (s *Scheduler) Run(ctx context.Context) {
+	// Start with a stopped, drained timer; it is only armed when a task waits.
+	timer := time.NewTimer(time.Hour)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop() // no leaked timer after cancellation
+
+	for {
+		due, wait, ok := s.next(time.Now())
+		for _, fn := range due {
+			fn()
+		}
+		if len(due) > 0 {
+			continue // running tasks took time — recheck the clock first
+		}
+
+		if ok {
+			timer.Reset(wait)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-s.wake:
+			// Disarm before re-arming; drain if it fired concurrently.
+			if ok && !timer.Stop() {
+				<-timer.C
+			}
+		}
+	}
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Timers & Scheduling")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── SleepContext ──────────────────────────────────────────────────────
+	fmt.Println("\n── SleepContext ──")
+	start := time.Now()
+	err := SleepContext(context.Background(), 15*time.Millisecond)
+	fmt.Printf("  full sleep: err=%v after ≥15ms: %v\n", err, time.Since(start) >= 15*time.Millisecond)
+
+	sctx, scancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	start = time.Now()
+	err = SleepContext(sctx, time.Hour)
+	scancel()
+	fmt.Printf("  1h sleep, ctx times out at 10ms: err=%v, returned in <100ms: %v\n",
+		err, time.Since(start) < 100*time.Millisecond)
+
+	// ── TickContext ───────────────────────────────────────────────────────
+	fmt.Println("\n── TickContext ──")
+	before := runtime.NumGoroutine()
+	tctx, tcancel := context.WithCancel(context.Background())
+	ticks := 0
+	for range TickContext(tctx, 5*time.Millisecond) {
+		ticks++
+		if ticks == 3 {
+			tcancel() // the range loop ends when the channel closes
+		}
+	}
+	time.Sleep(5 * time.Millisecond) // let the ticker goroutine exit
+	// (one tick may already be buffered when cancel runs, so ticks can be 4)
+	fmt.Printf("  range loop ended after cancel (ticks ≥ 3: %v); goroutines before=%d after=%d\n",
+		ticks >= 3, before, runtime.NumGoroutine())
+
+	// ── Scheduler: out-of-order scheduling ────────────────────────────────
+	fmt.Println("\n── Scheduler ──")
+	s := NewScheduler()
+	start = time.Now()
+	var (
+		mu  sync.Mutex
+		ran []string
+	)
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			ran = append(ran, name)
+			mu.Unlock()
+		}
+	}
+
+	// Scheduled in the order C, A, B — must run A, B, C.
+	s.Schedule(start.Add(60*time.Millisecond), record("C@60ms"))
+	s.Schedule(start.Add(20*time.Millisecond), record("A@20ms"))
+	s.Schedule(start.Add(40*time.Millisecond), record("B@40ms"))
+	s.Schedule(start.Add(-time.Second), record("past→now"))
+	s.Schedule(start.Add(time.Hour), record("never (1h)"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	// Scheduled while Run is already sleeping toward A@20ms — earlier than
+	// the armed timer, so it must wake the loop and run first.
+	time.Sleep(5 * time.Millisecond)
+	s.Schedule(time.Now().Add(5*time.Millisecond), record("late-added@10ms"))
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	<-done
+
+	mu.Lock()
+	fmt.Printf("  run order: %v\n", ran)
+	mu.Unlock()
+	fmt.Printf("  Run returned after cancel; still pending: %d\n", s.Pending())
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  SleepContext: own the timer, Stop it on cancel — no leak")
+	fmt.Println("  TickContext: ticker stopped and channel closed when ctx is done")
+	fmt.Println("  Scheduler: min-heap by runAt, one re-armed timer, wake on Schedule")
+}
@@ -0,0 +1,208 @@
+// FILE: 07_packages_modules/08_cli_flags/08_cli_flags.go
+// TOPIC: CLI Flags — a small typed FlagSet built on one generic helper
+//
+// Run: go run 07_packages_modules/08_cli_flags/08_cli_flags.go
+//
+// ─────────────────────────────────────────────────────────────────────────────
+// The standard `flag` package is the right default for real programs. This
+// file hand-rolls a tiny FlagSet to show how typed options can share ONE
+// generic registration function, and to accept the GNU-style forms people
+// type out of habit:
+//
+//   --port=8080   --port 8080   -port 8080   --verbose   --verbose=false
+//
+// Positional arguments may appear between flags; "--" ends flag parsing.
+// ─────────────────────────────────────────────────────────────────────────────
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ── FLAG VALUES ───────────────────────────────────────────────────────────────
+// Every flag, whatever its type, only needs to be set from a string. The
+// generic typedFlag[T] pairs a destination pointer with a parse function, so
+// StringVar/IntVar/BoolVar/DurationVar are each one line.
+
+type flagValue interface {
+	set(raw string) error
+	isBool() bool // bool flags may appear without a value
+}
+
+type typedFlag[T any] struct {
+	dest  *T
+	parse func(string) (T, error)
+}
+
+func (f *typedFlag[T]) set(raw string) error {
+	v, err := f.parse(raw)
+	if err != nil {
+		return err
+	}
+	*f.dest = v
+	return nil
+}
+
+func (f *typedFlag[T]) isBool() bool {
+	_, ok := any(f.dest).(*bool)
+	return ok
+}
+
+// ── FLAG SET ──────────────────────────────────────────────────────────────────
+
+var ErrUnknownFlag = errors.New("unknown flag")
+
+type FlagSet struct {
+	name  string
+	flags map[string]flagValue
+	usage map[string]string
+	args  []string
+}
+
+func NewFlagSet(name string) *FlagSet {
+	return &FlagSet{name: name, flags: make(map[string]flagValue), usage: make(map[string]string)}
+}
+
+// addVar registers a flag of any type; the typed methods below delegate here.
+func addVar[T any](fs *FlagSet, p *T, name string, def T, usage string, parse func(string) (T, error)) {
+	if _, dup := fs.flags[name]; dup {
+		panic(fmt.Sprintf("%s: flag redefined: %s", fs.name, name))
+	}
+	*p = def
+	fs.flags[name] = &typedFlag[T]{dest: p, parse: parse}
+	fs.usage[name] = fmt.Sprintf("--%s (default %v)  %s", name, def, usage)
+}
+
+func (fs *FlagSet) StringVar(p *string, name, def, usage string) {
+	addVar(fs, p, name, def, usage, func(s string) (string, error) { return s, nil })
+}
+
+func (fs *FlagSet) IntVar(p *int, name string, def int, usage string) {
+	addVar(fs, p, name, def, usage, strconv.Atoi)
+}
+
+func (fs *FlagSet) BoolVar(p *bool, name string, def bool, usage string) {
+	addVar(fs, p, name, def, usage, strconv.ParseBool)
+}
+
+func (fs *FlagSet) DurationVar(p *time.Duration, name string, def time.Duration, usage string) {
+	addVar(fs, p, name, def, usage, time.ParseDuration)
+}
+
+// Parse processes args (without the program name, i.e. os.Args[1:]).
+func (fs *FlagSet) Parse(args []string) error {
+	fs.args = nil
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			fs.args = append(fs.args, args[i+1:]...)
+			return nil
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			fs.args = append(fs.args, arg) // positional
+			continue
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		name, value, hasValue := strings.Cut(name, "=")
+		f, ok := fs.flags[name]
+		if !ok {
+			return fmt.Errorf("%s: %w: %s", fs.name, ErrUnknownFlag, arg)
+		}
+		if !hasValue {
+			switch {
+			case f.isBool():
+				value = "true" // bare --verbose
+			case i+1 < len(args):
+				i++
+				value = args[i] // --port 8080
+			default:
+				return fmt.Errorf("%s: flag --%s needs a value", fs.name, name)
+			}
+		}
+		if err := f.set(value); err != nil {
+			return fmt.Errorf("%s: invalid value %q for flag --%s: %w", fs.name, value, name, err)
+		}
+	}
+	return nil
+}
+
+// Args returns the positional arguments left after Parse.
+func (fs *FlagSet) Args() []string { return fs.args }
+
+// Usage lists the registered flags, sorted by name.
+func (fs *FlagSet) Usage() string {
+	names := make([]string, 0, len(fs.usage))
+	for n := range fs.usage {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	lines := make([]string, len(names))
+	for i, n := range names {
+		lines[i] = "  " + fs.usage[n]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ── CONFIG + FLAGS ────────────────────────────────────────────────────────────
+
+type ServerConfig struct {
+	Host    string
+	Port    int
+	Verbose bool
+	Timeout time.Duration
+}
+
+func newServerFlags(cfg *ServerConfig) *FlagSet {
+	fs := NewFlagSet("server")
+	fs.StringVar(&cfg.Host, "host", "localhost", "address to bind")
+	fs.IntVar(&cfg.Port, "port", 8080, "port to listen on")
+	fs.BoolVar(&cfg.Verbose, "verbose", false, "log every request")
+	fs.DurationVar(&cfg.Timeout, "timeout", 30*time.Second, "request timeout")
+	return fs
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: CLI Flags")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── Usage ─────────────────────────────────────────────────────────────
+	fmt.Println("\n── Registered flags ──")
+	var cfg ServerConfig
+	fmt.Println(newServerFlags(&cfg).Usage())
+
+	// ── Parsing ───────────────────────────────────────────────────────────
+	fmt.Println("\n── Parse ──")
+	cases := [][]string{
+		{},
+		{"--port=9090", "--host", "0.0.0.0", "-verbose", "--timeout", "5s", "serve"},
+		{"serve", "--verbose=false", "--port", "7000", "--", "--not-a-flag"},
+		{"--port", "eighty"},
+		{"--timeout"},
+		{"--colour=blue"},
+	}
+	for _, args := range cases {
+		var cfg ServerConfig
+		fs := newServerFlags(&cfg)
+		err := fs.Parse(args)
+		fmt.Printf("  %q\n", args)
+		if err != nil {
+			fmt.Printf("    error: %v (unknown=%v)\n", err, errors.Is(err, ErrUnknownFlag))
+			continue
+		}
+		fmt.Printf("    → %+v args=%q\n", cfg, fs.Args())
+	}
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  One generic addVar[T] + parse func → String/Int/Bool/DurationVar")
+	fmt.Println("  --k=v, --k v, -k v; bare bool flags; \"--\" ends flag parsing")
+	fmt.Println("  Unknown flags wrap ErrUnknownFlag; bad values name the flag")
+	fmt.Println("  Real programs: prefer the standard flag package")
+}
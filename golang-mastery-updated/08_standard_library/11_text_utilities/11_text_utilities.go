@@ -0,0 +1,168 @@
+// FILE: 08_standard_library/11_text_utilities/11_text_utilities.go
+// TOPIC: Text Utilities — rune-aware truncation, text statistics, nested joins
+//
+// Run: go run 08_standard_library/11_text_utilities/11_text_utilities.go
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ── TRUNCATE — cut by runes, never by bytes ──────────────────────────────────
+// s[:n] slices BYTES. On "héllo" or "日本語" that can split a multibyte rune
+// and leave invalid UTF-8 behind. Converting to []rune first makes every
+// index a whole character.
+//
+// Rules:
+//   - len in runes <= maxRunes → s returned unchanged (no ellipsis)
+//   - otherwise keep (maxRunes - runes(ellipsis)) runes and append ellipsis,
+//     so the result is never longer than maxRunes runes
+//   - if the ellipsis alone does not fit, it is dropped and s is hard-cut
+
+// Truncate shortens s to at most maxRunes runes, appending ellipsis only
+// when something was actually cut off.
+func Truncate(s string, maxRunes int, ellipsis string) string {
+	if maxRunes <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	runes := []rune(s)
+	keep := maxRunes - utf8.RuneCountInString(ellipsis)
+	if keep < 0 {
+		return string(runes[:maxRunes]) // ellipsis can't fit — hard cut
+	}
+	return string(runes[:keep]) + ellipsis
+}
+
+// ── TEXT STATISTICS — three different "lengths" ──────────────────────────────
+// len(s)            bytes      "é" (precomposed) = 2
+// CountRunes(s)     code points
+// CountGraphemes(s) what a human calls "characters" (approximately)
+//
+// "e\u0301" is e + COMBINING ACUTE ACCENT: 2 runes, but it renders as one "é".
+// CountGraphemes skips combining marks (Unicode category M), which fixes
+// accents and most diacritics.
+//
+// LIMITATION: this is NOT full UAX #29 segmentation. Emoji ZWJ sequences
+// (👨‍👩‍👧 = 5 runes), flag pairs (🇮🇳 = 2 regional indicators), and Hangul jamo
+// sequences are still over-counted. Use golang.org/x/text or
+// github.com/rivo/uniseg when exact grapheme clusters matter.
+
+// CountRunes returns the number of Unicode code points in s.
+func CountRunes(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// CountWords counts runs of non-space runes, splitting on unicode.IsSpace.
+// Consecutive, leading, and trailing whitespace never produce empty words.
+func CountWords(s string) int {
+	words, inWord := 0, false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			inWord = false
+		} else if !inWord {
+			inWord = true
+			words++
+		}
+	}
+	return words
+}
+
+// CountGraphemes approximates user-perceived characters by not counting
+// combining marks. See the limitation note above.
+func CountGraphemes(s string) int {
+	n := 0
+	for _, r := range s {
+		if !unicode.Is(unicode.M, r) {
+			n++
+		}
+	}
+	return n
+}
+
+// ── JOIN NESTED — two levels of strings.Join ─────────────────────────────────
+// Building "db.host,db.port,cache.ttl" or "a/b;c/d" is two joins: each group
+// with an inner separator, then the groups with an outer one.
+//
+//   JoinNested([][]string{{"db","host"}, {}, {"cache","ttl"}}, ".", ",")
+//     → "db.host,cache.ttl"
+//
+// Empty inner slices are SKIPPED rather than producing an empty field, so
+// there's never a doubled outer separator. (Empty strings inside a group are
+// kept — that's the caller's data.) Built in a single strings.Builder pass.
+
+// JoinNested joins each inner slice with inner, then the results with outer.
+func JoinNested(parts [][]string, inner, outer string) string {
+	var sb strings.Builder
+	first := true
+	for _, group := range parts {
+		if len(group) == 0 {
+			continue
+		}
+		if !first {
+			sb.WriteString(outer)
+		}
+		first = false
+		for i, p := range group {
+			if i > 0 {
+				sb.WriteString(inner)
+			}
+			sb.WriteString(p)
+		}
+	}
+	return sb.String()
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Text Utilities")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── Truncate ──────────────────────────────────────────────────────────
+	fmt.Println("\n── Truncate ──")
+	fmt.Printf("  ASCII, cut:        %q\n", Truncate("Hello, Gophers!", 8, "..."))
+	fmt.Printf("  within limit:      %q\n", Truncate("short", 10, "..."))
+	fmt.Printf("  exactly at limit:  %q\n", Truncate("exact", 5, "..."))
+	fmt.Printf("  CJK:               %q\n", Truncate("日本語のテキスト", 5, "…"))
+	fmt.Printf("  emoji:             %q\n", Truncate("🚀🔥🎉✨🌍", 3, "…"))
+	fmt.Printf("  limit < ellipsis:  %q\n", Truncate("abcdef", 2, "..."))
+	fmt.Printf("  zero limit:        %q\n", Truncate("abcdef", 0, "..."))
+
+	// Byte slicing vs rune slicing on the same input:
+	s := "日本語"
+	fmt.Printf("  s[:4] bytes:       %q valid=%v\n", s[:4], utf8.ValidString(s[:4]))
+	fmt.Printf("  Truncate(s, 2,\"\"): %q valid=%v\n", Truncate(s, 2, ""), utf8.ValidString(Truncate(s, 2, "")))
+
+	// ── CountRunes / CountWords / CountGraphemes ──────────────────────────
+	fmt.Println("\n── Text statistics ──")
+	for _, t := range []string{"hello", "héllo", "he\u0301llo", "日本語", "🚀 go"} {
+		fmt.Printf("  %-10q bytes=%-2d runes=%-2d graphemes≈%d\n",
+			t, len(t), CountRunes(t), CountGraphemes(t))
+	}
+	fmt.Printf("  family emoji 👨‍👩‍👧: runes=%d graphemes≈%d (really 1 — see limitation)\n",
+		CountRunes("👨‍👩‍👧"), CountGraphemes("👨‍👩‍👧"))
+
+	fmt.Printf("  CountWords(\"  the   quick\\t\\nfox  \"): %d\n", CountWords("  the   quick\t\nfox  "))
+	fmt.Printf("  CountWords(\"日本 語\u3000テキスト\"):     %d (U+3000 ideographic space)\n", CountWords("日本 語\u3000テキスト"))
+	fmt.Printf("  CountWords(\"   \"):                    %d\n", CountWords("   "))
+
+	// ── JoinNested ────────────────────────────────────────────────────────
+	fmt.Println("\n── JoinNested ──")
+	keys := [][]string{{"db", "host"}, {"db", "port"}, {}, {"cache", "ttl"}}
+	fmt.Printf("  dotted keys:   %q\n", JoinNested(keys, ".", ","))
+	fmt.Printf("  path list:     %q\n", JoinNested([][]string{{"usr", "local", "bin"}, {"opt", "go", "bin"}}, "/", ":"))
+	fmt.Printf("  single group:  %q\n", JoinNested([][]string{{"a", "b", "c"}}, "-", "|"))
+	fmt.Printf("  all empty:     %q\n", JoinNested([][]string{{}, nil}, ".", ","))
+	fmt.Printf("  no groups:     %q\n", JoinNested(nil, ".", ","))
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  Truncate: limit counted in runes, ellipsis counted against the limit")
+	fmt.Println("  CountRunes / CountWords / CountGraphemes: bytes ≠ runes ≠ characters")
+	fmt.Println("  JoinNested: inner join per group, outer join across; empty groups skipped")
+}
@@ -0,0 +1,204 @@
+// FILE: 08_standard_library/12_json_utilities/12_json_utilities.go
+// TOPIC: JSON Utilities — merge patch and path lookup on generic (map[string]interface{}) JSON
+//
+// Run: go run 08_standard_library/12_json_utilities/12_json_utilities.go
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ── JSON MERGE PATCH (RFC 7386) ───────────────────────────────────────────────
+// The format behind most PATCH endpoints (Content-Type: application/merge-patch+json).
+// The patch looks like the document itself, and the rules are:
+//
+//   - patch is an object → merge key by key, recursively
+//       · "key": null      → delete key from the target
+//       · "key": <object>  → merge into the target's value (which becomes {}
+//                            first if it wasn't an object)
+//       · "key": <other>   → replace
+//   - patch is anything else (array, string, number, ...) → it REPLACES the
+//     target wholesale. Arrays are never merged element by element.
+//
+// Decoding into interface{} gives us exactly the shapes the RFC talks about:
+// map[string]interface{}, []interface{}, string, number, bool, nil.
+//
+// Numbers are decoded with UseNumber, as json.Number (the original digits),
+// NOT float64: a float64 only holds integers exactly up to 2^53, so an ID
+// like 9007199254740993 would come back as ...992 — changing a field the
+// patch never touched. json.Number is re-encoded digit for digit.
+
+// MergePatch applies patch to original and returns the resulting JSON.
+func MergePatch(original, patch []byte) ([]byte, error) {
+	var target, p interface{}
+	if err := decodeUseNumber(original, &target); err != nil {
+		return nil, fmt.Errorf("merge patch: invalid original: %w", err)
+	}
+	if err := decodeUseNumber(patch, &p); err != nil {
+		return nil, fmt.Errorf("merge patch: invalid patch: %w", err)
+	}
+	return json.Marshal(mergeValue(target, p))
+}
+
+// decodeUseNumber is json.Unmarshal with numbers kept as json.Number. Like
+// Unmarshal, it rejects anything after the first value.
+func decodeUseNumber(data []byte, v *interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("unexpected data after top-level value")
+	}
+	return nil
+}
+
+func mergeValue(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch // non-object patch replaces the target
+	}
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(targetObj, k)
+			continue
+		}
+		targetObj[k] = mergeValue(targetObj[k], v)
+	}
+	return targetObj
+}
+
+// ── JSON PATH — dotted lookup instead of nested type assertions ──────────────
+// Without a helper, reading address.city out of generic JSON is:
+//
+//   addr, ok := m["address"].(map[string]interface{})
+//   if !ok { ... }
+//   city, ok := addr["city"].(string)
+//
+// JSONPath walks a dotted path instead. Each segment is an object key, or —
+// when the current value is an array — a 0-based index: "tags.0",
+// "orders.1.items.0.sku". An empty path returns the whole document.
+//
+// Errors name the path prefix that failed, so "orders.5.sku" on a 2-element
+// array says exactly which step broke. Numbers come back as float64, as with
+// any decode into interface{}.
+
+// JSONPath decodes data and returns the value at the dotted path.
+func JSONPath(data []byte, path string) (any, error) {
+	var cur any
+	if err := json.Unmarshal(data, &cur); err != nil {
+		return nil, fmt.Errorf("json path: invalid JSON: %w", err)
+	}
+	if path == "" {
+		return cur, nil
+	}
+	segments := strings.Split(path, ".")
+	for i, seg := range segments {
+		at := strings.Join(segments[:i+1], ".")
+		switch node := cur.(type) {
+		case map[string]interface{}:
+			v, ok := node[seg]
+			if !ok {
+				return nil, fmt.Errorf("json path %q: key %q not found", at, seg)
+			}
+			cur = v
+		case []interface{}:
+			idx, err := strconv.Atoi(seg)
+			if err != nil {
+				return nil, fmt.Errorf("json path %q: %q is not an array index", at, seg)
+			}
+			if idx < 0 || idx >= len(node) {
+				return nil, fmt.Errorf("json path %q: index %d out of range (len %d)", at, idx, len(node))
+			}
+			cur = node[idx]
+		default:
+			return nil, fmt.Errorf("json path %q: cannot index into %T", at, cur)
+		}
+	}
+	return cur, nil
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: JSON Utilities")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── MergePatch ────────────────────────────────────────────────────────
+	fmt.Println("\n── MergePatch (RFC 7386) ──")
+	user := `{"name":"Alice","email":"a@old.com","phone":"555-0100",` +
+		`"address":{"city":"Pune","zip":"411001"},"tags":["admin","beta"]}`
+
+	cases := []struct{ label, patch string }{
+		{"delete via null", `{"phone":null}`},
+		{"nested merge", `{"address":{"zip":"411002","country":"IN"}}`},
+		{"array replaced", `{"tags":["user"]}`},
+		{"nested delete", `{"address":{"city":null}}`},
+		{"scalar → object", `{"name":{"first":"Alice"}}`},
+	}
+	fmt.Printf("  original: %s\n", user)
+	for _, c := range cases {
+		out, err := MergePatch([]byte(user), []byte(c.patch))
+		if err != nil {
+			fmt.Printf("  %-16s error: %v\n", c.label, err)
+			continue
+		}
+		fmt.Printf("  %-16s patch %s\n  %-16s → %s\n", c.label+":", c.patch, "", out)
+	}
+
+	// Large integers in untouched fields survive byte for byte:
+	order := `{"id":9007199254740993,"status":"pending","total":12.50}`
+	out, _ := MergePatch([]byte(order), []byte(`{"status":"shipped"}`))
+	fmt.Printf("  id above 2^53:  %s\n  %-16s → %s\n", order, "", out)
+	var viaFloat map[string]interface{}
+	json.Unmarshal([]byte(order), &viaFloat)
+	fmt.Printf("  (plain Unmarshal would have turned the id into %.0f)\n", viaFloat["id"])
+
+	// Non-object patch replaces the whole document:
+	out, _ = MergePatch([]byte(`{"a":1}`), []byte(`[1,2]`))
+	fmt.Printf("  whole-document replace: %s\n", out)
+
+	_, err := MergePatch([]byte(`{"a":1}`), []byte(`{bad json`))
+	fmt.Printf("  invalid patch: %v\n", err)
+
+	// ── JSONPath ──────────────────────────────────────────────────────────
+	fmt.Println("\n── JSONPath ──")
+	doc := []byte(`{"name":"Alice","address":{"city":"Pune","geo":{"lat":18.52}},` +
+		`"tags":["admin","beta"],"orders":[{"id":1,"items":[{"sku":"A-1"}]},{"id":2,"items":[]}]}`)
+	for _, path := range []string{
+		"address.city",
+		"address.geo.lat",
+		"tags.0",
+		"orders.0.items.0.sku",
+		"orders.1.id",
+		"tags",
+	} {
+		v, err := JSONPath(doc, path)
+		fmt.Printf("  %-22s → %v (%T) err=%v\n", path, v, v, err)
+	}
+	for _, path := range []string{
+		"address.zip",
+		"tags.5",
+		"tags.first",
+		"name.first",
+		"orders.1.items.0",
+	} {
+		_, err := JSONPath(doc, path)
+		fmt.Printf("  %-22s → %v\n", path, err)
+	}
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  MergePatch: objects merge recursively, null deletes, arrays replace")
+	fmt.Println("  JSONPath: dotted keys / indices, errors name the failing prefix")
+}
@@ -5,7 +5,12 @@
 
 package main
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // ── CONSTRAINTS ──────────────────────────────────────────────────────────────
 type Number interface {
@@ -18,9 +23,30 @@ type Number interface {
 
 // Map transforms each element: []T → []R
 func Map[T, R any](s []T, f func(T) R) []R {
+	return MapIndexed(s, func(_ int, v T) R { return f(v) })
+}
+
+// MapIndexed is Map with the element's index passed to f, for when the
+// position matters (numbering, alternating styles, offsets).
+func MapIndexed[T, R any](s []T, f func(i int, v T) R) []R {
 	result := make([]R, len(s))
 	for i, v := range s {
-		result[i] = f(v)
+		result[i] = f(i, v)
+	}
+	return result
+}
+
+// FlatMap expands each element into zero or more results and concatenates
+// them: []T → []R. A nil or empty expansion contributes nothing.
+func FlatMap[T, R any](s []T, f func(T) []R) []R {
+	return FlatMapIndexed(s, func(_ int, v T) []R { return f(v) })
+}
+
+// FlatMapIndexed is FlatMap with the element's index passed to f.
+func FlatMapIndexed[T, R any](s []T, f func(i int, v T) []R) []R {
+	var result []R
+	for i, v := range s {
+		result = append(result, f(i, v)...)
 	}
 	return result
 }
@@ -45,6 +71,57 @@ func Reduce[T, Acc any](s []T, initial Acc, f func(Acc, T) Acc) Acc {
 	return acc
 }
 
+// ReduceWhile is Reduce with early exit: f returns the new accumulator and
+// whether to keep going. Elements after the stop are never visited.
+func ReduceWhile[T, R any](s []T, initial R, f func(R, T) (R, bool)) R {
+	acc := initial
+	for _, v := range s {
+		var cont bool
+		acc, cont = f(acc, v)
+		if !cont {
+			break
+		}
+	}
+	return acc
+}
+
+// Unfold is the dual of Reduce: instead of folding a slice into a state, it
+// grows a slice from a state. fn gets the current state and returns the next
+// element, the next state, and whether to emit that element and go on;
+// false stops generation (the element returned with it is discarded).
+// Unfold has no length limit — termination is the caller's job. Make sure
+// fn eventually returns false, or bound it with a counter in the state.
+func Unfold[T, S any](seed S, fn func(S) (T, S, bool)) []T {
+	var result []T
+	state := seed
+	for {
+		v, next, ok := fn(state)
+		if !ok {
+			return result
+		}
+		result = append(result, v)
+		state = next
+	}
+}
+
+// ── ITERATION ─────────────────────────────────────────────────────────────────
+
+// ForEach calls f on every element, in order.
+func ForEach[T any](s []T, f func(v T)) {
+	for _, v := range s {
+		f(v)
+	}
+}
+
+// ForEachIndexed calls f with each index and element until f returns false.
+func ForEachIndexed[T any](s []T, f func(i int, v T) bool) {
+	for i, v := range s {
+		if !f(i, v) {
+			return
+		}
+	}
+}
+
 // ── UTILITY FUNCTIONS ─────────────────────────────────────────────────────────
 
 func Contains[T comparable](s []T, v T) bool {
@@ -66,6 +143,27 @@ func Find[T any](s []T, f func(T) bool) (T, bool) {
 	return zero, false
 }
 
+// CountWhere counts matching elements in one pass — no len(Filter(...))
+// intermediate slice.
+func CountWhere[T any](s []T, pred func(T) bool) int {
+	n := 0
+	for _, v := range s {
+		if pred(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// CountDistinct returns the number of unique elements.
+func CountDistinct[T comparable](s []T) int {
+	seen := make(map[T]struct{}, len(s))
+	for _, v := range s {
+		seen[v] = struct{}{}
+	}
+	return len(seen)
+}
+
 func Keys[K comparable, V any](m map[K]V) []K {
 	keys := make([]K, 0, len(m))
 	for k := range m {
@@ -103,6 +201,21 @@ func Sum[T Number](s []T) T {
 	return total
 }
 
+// ErrDivideByZero is returned by SafeDivide for a zero denominator.
+var ErrDivideByZero = errors.New("division by zero")
+
+// SafeDivide returns a / b, or ErrDivideByZero when b is zero.
+// Integer division by zero panics while float division quietly yields ±Inf
+// or NaN; SafeDivide treats both the same way so callers handle one error.
+// For integers the result truncates toward zero, like the / operator.
+func SafeDivide[T Number](a, b T) (T, error) {
+	var zero T
+	if b == zero {
+		return zero, ErrDivideByZero
+	}
+	return a / b, nil
+}
+
 // Min/Max
 func Min[T Number | ~string](a, b T) T {
 	if a < b { return a }
@@ -125,6 +238,61 @@ func Chunk[T any](s []T, n int) [][]T {
 	return result
 }
 
+// ChunkByWeight splits s into consecutive chunks whose total weight stays
+// within maxWeight — batching by payload bytes or cost instead of count.
+// A chunk is closed when adding the next element would EXCEED maxWeight, so
+// an exact fit stays in the current chunk. An element heavier than
+// maxWeight on its own can't fit anywhere: it gets a chunk to itself rather
+// than being dropped, so check for it if the limit is hard.
+func ChunkByWeight[T any](s []T, maxWeight int, weightFn func(T) int) [][]T {
+	var result [][]T
+	start, total := 0, 0
+	for i, v := range s {
+		w := weightFn(v)
+		if i > start && total+w > maxWeight {
+			result = append(result, s[start:i])
+			start, total = i, 0
+		}
+		total += w
+	}
+	if start < len(s) {
+		result = append(result, s[start:])
+	}
+	return result
+}
+
+// ── BATCH EXECUTION — Chunk + context ─────────────────────────────────────────
+// Bulk APIs and multi-row INSERTs take items in batches. ExecuteBatch splits
+// items with Chunk, calls fn once per batch (sequentially), and concatenates
+// the results in order.
+//   - ctx is checked before each batch, and passed to fn so a batch in flight
+//     can be cancelled too
+//   - the first failing batch stops everything; results from the batches
+//     that already succeeded are returned alongside the error, so callers
+//     know what was committed
+//   - the last batch may be smaller than batchSize
+
+// ExecuteBatch runs fn over items in batches of batchSize.
+func ExecuteBatch[T, R any](ctx context.Context, items []T, batchSize int,
+	fn func(ctx context.Context, batch []T) ([]R, error)) ([]R, error) {
+	if batchSize < 1 {
+		return nil, errors.New("ExecuteBatch: batchSize must be at least 1")
+	}
+	results := make([]R, 0, len(items))
+	for i, batch := range Chunk(items, batchSize) {
+		if err := ctx.Err(); err != nil {
+			return results, fmt.Errorf("batch %d not started: %w", i, err)
+		}
+		out, err := fn(ctx, batch)
+		if err != nil {
+			return results, fmt.Errorf("batch %d (items %d-%d): %w",
+				i, i*batchSize, i*batchSize+len(batch)-1, err)
+		}
+		results = append(results, out...)
+	}
+	return results, nil
+}
+
 func main() {
 	fmt.Println("════════════════════════════════════════")
 	fmt.Println("  Topic: Generic Functions")
@@ -139,6 +307,30 @@ func main() {
 	strs := Map(ints, func(n int) string { return fmt.Sprintf("item%d", n) })
 	fmt.Printf("  to strings: %v\n", strs)
 
+	steps := MapIndexed([]string{"clone", "build", "test"}, func(i int, s string) string {
+		return fmt.Sprintf("%d. %s", i+1, s)
+	})
+	fmt.Printf("  MapIndexed numbering: %q\n", steps)
+
+	// ── FlatMap / FlatMapIndexed ──────────────────────────────────────────
+	fmt.Println("\n── FlatMap / FlatMapIndexed ──")
+	tags := FlatMap([]string{"go,generics", "", "channels"}, func(csv string) []string {
+		if csv == "" {
+			return nil // contributes nothing
+		}
+		return strings.Split(csv, ",")
+	})
+	fmt.Printf("  FlatMap split tags: %q\n", tags)
+	// Element i is repeated i times — the expansion depends on position:
+	stairs := FlatMapIndexed([]string{"a", "b", "c", "d"}, func(i int, s string) []string {
+		out := make([]string, i)
+		for k := range out {
+			out[k] = s
+		}
+		return out
+	})
+	fmt.Printf("  FlatMapIndexed repeat ×index: %q (\"a\" at index 0 → nothing)\n", stairs)
+
 	// ── Filter ───────────────────────────────────────────────────────────
 	fmt.Println("\n── Filter ──")
 	evens := Filter(ints, func(n int) bool { return n%2 == 0 })
@@ -155,6 +347,66 @@ func main() {
 	concat := Reduce([]string{"a", "b", "c"}, "", func(acc, s string) string { return acc + s })
 	fmt.Printf("  concat: %q\n", concat)
 
+	// ── ReduceWhile — short-circuiting fold ──────────────────────────────
+	fmt.Println("\n── ReduceWhile ──")
+	visited := 0
+	budget := ReduceWhile([]int{40, 30, 20, 50, 10}, 100, func(left, cost int) (int, bool) {
+		visited++
+		if cost > left {
+			return left, false // can't afford this one — stop here
+		}
+		return left - cost, true
+	})
+	fmt.Printf("  budget 100, costs [40 30 20 50 10]: left=%d, visited=%d of 5\n", budget, visited)
+
+	type check struct {
+		name string
+		ok   bool
+	}
+	firstFailure := ReduceWhile([]check{{"db", true}, {"cache", false}, {"queue", false}}, "",
+		func(_ string, c check) (string, bool) { return c.name, c.ok })
+	fmt.Printf("  first failing check: %q\n", firstFailure)
+
+	// ── ForEach / ForEachIndexed ──────────────────────────────────────────
+	fmt.Println("\n── ForEach / ForEachIndexed ──")
+	total := 0
+	ForEach(ints, func(n int) { total += n })
+	fmt.Printf("  ForEach sum of %v: %d\n", ints, total)
+
+	lines := []string{"GET /", "GET /about", "", "GET /never-seen"}
+	var seen []int
+	ForEachIndexed(lines, func(i int, line string) bool {
+		seen = append(seen, i)
+		return line != "" // blank line ends the headers
+	})
+	fmt.Printf("  ForEachIndexed stops at the blank line: visited indices %v of %d\n", seen, len(lines))
+
+	// ── Unfold ────────────────────────────────────────────────────────────
+	fmt.Println("\n── Unfold ──")
+	stepped := Unfold(0, func(n int) (int, int, bool) { return n, n + 2, n < 10 })
+	fmt.Printf("  range 0..<10 step 2: %v\n", stepped)
+
+	type fibState struct{ a, b, left int }
+	fibs := Unfold(fibState{0, 1, 10}, func(s fibState) (int, fibState, bool) {
+		return s.a, fibState{s.b, s.a + s.b, s.left - 1}, s.left > 0 // counter bounds it
+	})
+	fmt.Printf("  first 10 Fibonacci: %v\n", fibs)
+
+	collatz := Unfold(6, func(n int) (int, int, bool) {
+		if n == 0 {
+			return 0, 0, false // 0 marks "already emitted 1"
+		}
+		if n == 1 {
+			return 1, 0, true
+		}
+		if n%2 == 0 {
+			return n, n / 2, true
+		}
+		return n, 3*n + 1, true
+	})
+	fmt.Printf("  Collatz chain from 6: %v\n", collatz)
+	fmt.Printf("  stop immediately: %v\n", Unfold(0, func(n int) (int, int, bool) { return n, n, false }))
+
 	// ── Chaining ──────────────────────────────────────────────────────────
 	fmt.Println("\n── Chaining Map+Filter+Reduce ──")
 	result := Reduce(
@@ -175,6 +427,16 @@ func main() {
 	v, ok := Find([]int{1, 2, 3, 4}, func(n int) bool { return n > 2 })
 	fmt.Printf("  Find(>2): %d, found=%v\n", v, ok)
 
+	// ── CountWhere / CountDistinct ────────────────────────────────────────
+	fmt.Println("\n── CountWhere / CountDistinct ──")
+	scores := []int{72, 95, 88, 95, 40, 88, 100}
+	passing := func(n int) bool { return n >= 70 }
+	fmt.Printf("  CountWhere(%v, >=70): %d\n", scores, CountWhere(scores, passing))
+	fmt.Printf("  CountWhere(all match): %d\n", CountWhere([]int{80, 90}, passing))
+	fmt.Printf("  CountWhere(empty): %d\n", CountWhere([]int{}, passing))
+	fmt.Printf("  CountDistinct(%v): %d\n", scores, CountDistinct(scores))
+	fmt.Printf("  CountDistinct(empty): %d\n", CountDistinct([]string{}))
+
 	// ── Map keys/values ───────────────────────────────────────────────────
 	fmt.Println("\n── Keys / Values ──")
 	m := map[string]int{"a": 1, "b": 2, "c": 3}
@@ -194,16 +456,69 @@ func main() {
 	fmt.Printf("  Min(3,7): %d\n", Min(3, 7))
 	fmt.Printf("  Max(\"apple\",\"banana\"): %q\n", Max("apple", "banana"))
 
+	// ── SafeDivide ────────────────────────────────────────────────────────
+	fmt.Println("\n── SafeDivide ──")
+	q, err := SafeDivide(7, 2)
+	fmt.Printf("  SafeDivide(7, 2): %d err=%v\n", q, err)
+	q, err = SafeDivide(7, 0)
+	fmt.Printf("  SafeDivide(7, 0): %d err=%v (plain 7/0 would panic)\n", q, err)
+	fq, err := SafeDivide(1.0, 4.0)
+	fmt.Printf("  SafeDivide(1.0, 4.0): %g err=%v\n", fq, err)
+	fq, err = SafeDivide(1.0, 0.0)
+	fmt.Printf("  SafeDivide(1.0, 0.0): %g err=%v (plain 1.0/0.0 would be +Inf)\n", fq, err)
+	_, err = SafeDivide(uint8(9), 0)
+	fmt.Printf("  uint8 zero denominator is ErrDivideByZero: %v\n", errors.Is(err, ErrDivideByZero))
+
 	// ── Chunk ─────────────────────────────────────────────────────────────
 	fmt.Println("\n── Chunk ──")
 	chunks := Chunk([]int{1, 2, 3, 4, 5, 6, 7}, 3)
 	fmt.Printf("  Chunk([1..7], 3): %v\n", chunks)
+	payloads := []string{"aaaa", "bbb", "ccc", "dd", "eeeeeeeeeeee", "f", "gg"}
+	size := func(s string) int { return len(s) }
+	fmt.Printf("  ChunkByWeight(bytes ≤ 7):  %v\n", ChunkByWeight(payloads, 7, size))
+	fmt.Println("    (4+3 = 7 fits exactly; the 12-byte payload gets its own chunk)")
+	fmt.Printf("  ChunkByWeight(empty):      %v\n", ChunkByWeight([]string{}, 7, size))
+
+	// ── ExecuteBatch ──────────────────────────────────────────────────────
+	fmt.Println("\n── ExecuteBatch ──")
+	ids := []int{1, 2, 3, 4, 5, 6, 7}
+	calls := 0
+	insert := func(ctx context.Context, batch []int) ([]string, error) {
+		calls++
+		return Map(batch, func(id int) string { return fmt.Sprintf("row-%d", id) }), nil
+	}
+	rows, err := ExecuteBatch(context.Background(), ids, 3, insert)
+	fmt.Printf("  7 items, batch 3: %d calls, %v err=%v\n", calls, rows, err)
+
+	errDup := errors.New("duplicate key")
+	failSecond := func(ctx context.Context, batch []int) ([]string, error) {
+		if batch[0] == 4 {
+			return nil, errDup
+		}
+		return Map(batch, func(id int) string { return fmt.Sprint(id) }), nil
+	}
+	rows, err = ExecuteBatch(context.Background(), ids, 3, failSecond)
+	fmt.Printf("  batch 1 fails: committed %v, err=%v, is errDup=%v\n", rows, err, errors.Is(err, errDup))
+
+	cctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rows, err = ExecuteBatch(cctx, ids, 3, insert)
+	fmt.Printf("  cancelled ctx: %v err=%v\n", rows, err)
 
 	fmt.Println("\n─── SUMMARY ────────────────────────────────")
 	fmt.Println("  Map[T,R] / Filter[T] / Reduce[T,Acc] — functional trio")
+	fmt.Println("  MapIndexed[T,R] — Map with the index; Map delegates to it")
+	fmt.Println("  FlatMap[T,R] / FlatMapIndexed[T,R] — expand to []R and concatenate")
+	fmt.Println("  ReduceWhile[T,R] — fold that can stop early")
+	fmt.Println("  ForEach[T] / ForEachIndexed[T] — range loop; return false to stop early")
+	fmt.Println("  Unfold[T,S] — generate from a seed until fn says stop (caller bounds it)")
 	fmt.Println("  Contains[T comparable] / Find[T any]")
+	fmt.Println("  CountWhere[T] / CountDistinct[T] — one-pass counting")
 	fmt.Println("  Keys[K,V] / Values[K,V] — map utilities")
 	fmt.Println("  Ptr[T] — pointer to value (useful for optional fields)")
 	fmt.Println("  Sum[T Number] — typed generic arithmetic")
+	fmt.Println("  SafeDivide[T Number] — zero denominator is an error for ints AND floats")
+	fmt.Println("  ChunkByWeight[T] — batch by total weight; oversized element stands alone")
+	fmt.Println("  ExecuteBatch[T,R] — Chunk + ctx, stop at first failing batch")
 	fmt.Println("  Type inference works for most calls — no explicit [T] needed")
 }
@@ -40,6 +40,33 @@ func ResultMap[T, U any](r Result[T], f func(T) U) Result[U] {
 	return OK(f(r.value))
 }
 
+// ── RESULTS IN SLICES ─────────────────────────────────────────────────────────
+// After a batch of fallible calls, two questions: "did everything work?"
+// (CollectResults — the first Err, in slice order, wins) and "what worked,
+// what didn't?" (PartitionResults — keep both sides, order preserved).
+
+func CollectResults[T any](rs []Result[T]) ([]T, error) {
+	values := make([]T, 0, len(rs))
+	for _, r := range rs {
+		if r.err != nil {
+			return nil, r.err
+		}
+		values = append(values, r.value)
+	}
+	return values, nil
+}
+
+func PartitionResults[T any](rs []Result[T]) (oks []T, errs []error) {
+	for _, r := range rs {
+		if r.err != nil {
+			errs = append(errs, r.err)
+		} else {
+			oks = append(oks, r.value)
+		}
+	}
+	return oks, errs
+}
+
 // ── OPTION[T] — explicitly optional values ────────────────────────────────────
 // Replaces the nil pointer pattern with a typed optional.
 
@@ -62,6 +89,33 @@ func (o Option[T]) Unwrap() T {
 	return o.value
 }
 
+// ── OPTIONS IN SLICES ─────────────────────────────────────────────────────────
+// FilterSome is the lenient bridge: keep what's there, skip what isn't.
+// TraverseOption is the strict one: all-or-nothing. It stops calling fn at
+// the first None, since the answer can no longer be Some.
+
+func FilterSome[T any](opts []Option[T]) []T {
+	var result []T
+	for _, o := range opts {
+		if o.hasValue {
+			result = append(result, o.value)
+		}
+	}
+	return result
+}
+
+func TraverseOption[T, R any](s []T, fn func(T) Option[R]) Option[[]R] {
+	result := make([]R, 0, len(s))
+	for _, v := range s {
+		o := fn(v)
+		if !o.hasValue {
+			return None[[]R]()
+		}
+		result = append(result, o.value)
+	}
+	return Some(result)
+}
+
 // ── GENERIC CACHE ─────────────────────────────────────────────────────────────
 type Cache[K comparable, V any] struct {
 	mu    sync.RWMutex
@@ -121,6 +175,22 @@ func main() {
 	r4 := ResultMap(r2, func(n int) string { return "never called" })
 	fmt.Printf("  ResultMap(Err, toString): isOK=%v, err=%v\n", r4.IsOK(), r4.Error())
 
+	parsePort := func(s string) Result[int] {
+		var p int
+		if _, err := fmt.Sscanf(s, "%d", &p); err != nil || p < 1 || p > 65535 {
+			return Err[int](fmt.Errorf("bad port %q", s))
+		}
+		return OK(p)
+	}
+	good := []Result[int]{parsePort("80"), parsePort("443"), parsePort("8080")}
+	mixed := []Result[int]{parsePort("80"), parsePort("http"), parsePort("443"), parsePort("99999")}
+	ports, err := CollectResults(good)
+	fmt.Printf("  CollectResults(all OK): %v err=%v\n", ports, err)
+	ports, err = CollectResults(mixed)
+	fmt.Printf("  CollectResults(2 Errs): %v err=%v (first one)\n", ports, err)
+	oks, errs := PartitionResults(mixed)
+	fmt.Printf("  PartitionResults: %d ok %v, %d err %v\n", len(oks), oks, len(errs), errs)
+
 	// ── Option[T] ─────────────────────────────────────────────────────────
 	fmt.Println("\n── Option[T] ──")
 	some := Some("hello")
@@ -139,6 +209,18 @@ func main() {
 	fmt.Printf("  findUser(1): %q\n", findUser(1).ValueOr("unknown"))
 	fmt.Printf("  findUser(99): %q\n", findUser(99).ValueOr("unknown"))
 
+	lookups := []Option[string]{findUser(1), findUser(99), findUser(2)}
+	fmt.Printf("  FilterSome(findUser 1, 99, 2): %q\n", FilterSome(lookups))
+	all := TraverseOption([]int{1, 2}, findUser)
+	fmt.Printf("  TraverseOption([1 2]): isSome=%v %q\n", all.IsSome(), all.ValueOr(nil))
+	lookedUp := 0
+	countingFind := func(id int) Option[string] {
+		lookedUp++
+		return findUser(id)
+	}
+	missing := TraverseOption([]int{1, 99, 2}, countingFind)
+	fmt.Printf("  TraverseOption([1 99 2]): isNone=%v, stopped after %d lookups\n", missing.IsNone(), lookedUp)
+
 	// ── Generic Cache ─────────────────────────────────────────────────────
 	fmt.Println("\n── Generic Cache[K,V] ──")
 	cache := NewCache[string, int]()
@@ -181,7 +263,9 @@ func main() {
 
 	fmt.Println("─── SUMMARY ────────────────────────────────")
 	fmt.Println("  Result[T]: typed success/failure, chainable with ResultMap")
+	fmt.Println("  CollectResults: all values or first error; PartitionResults keeps both")
 	fmt.Println("  Option[T]: explicit optional (vs nil pointer)")
+	fmt.Println("  FilterSome drops Nones; TraverseOption is Some only if all are Some")
 	fmt.Println("  Cache[K,V]: type-safe concurrent cache")
 	fmt.Println("  Generics shine for: containers, algorithms, utilities")
 	fmt.Println("  Use interface when behavior differs per type at runtime")
@@ -0,0 +1,750 @@
+// FILE: 09_generics/08_slice_utilities/08_slice_utilities.go
+// TOPIC: Slice Utilities — flattening, grouping, zipping, reshaping
+//
+// Run: go run 09_generics/08_slice_utilities/08_slice_utilities.go
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// ── FLATTEN ANY — reflection fallback for unknown nesting ────────────────────
+// Generics can express [][]T, but not "a slice nested to ANY depth".
+// Data decoded from JSON/config ([]any{1, []any{2, 3}}) has unknown depth,
+// so we fall back to reflect and walk slices/arrays recursively.
+//
+// Strings and maps are treated as leaves: a string is not "a slice of bytes"
+// for this purpose, and maps have no order to flatten into.
+
+// FlattenAny recursively flattens nested slices/arrays into a flat []any.
+// A non-slice value is returned as a single-element result.
+func FlattenAny(v any) []any {
+	result := []any{}
+	flattenInto(reflect.ValueOf(v), &result)
+	return result
+}
+
+func flattenInto(rv reflect.Value, out *[]any) {
+	// Unwrap interfaces: []any elements arrive as Interface-kind values
+	for rv.Kind() == reflect.Interface && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			flattenInto(rv.Index(i), out)
+		}
+	case reflect.Invalid:
+		*out = append(*out, nil) // untyped nil leaf
+	default:
+		*out = append(*out, rv.Interface())
+	}
+}
+
+// ── PAIR ──────────────────────────────────────────────────────────────────────
+// Same shape as Pair in 04_generic_types — each file here is standalone.
+
+type Pair[K, V any] struct {
+	Key   K
+	Value V
+}
+
+func (p Pair[K, V]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.Key, p.Value)
+}
+
+// ── GROUPING — global vs consecutive ──────────────────────────────────────────
+// GroupBy collects every element with the same key, wherever it appears.
+// GroupConsecutive only merges RUNS of equal keys — a key that reappears later
+// starts a new group. That is run-length encoding, and the natural way to
+// process an already-sorted stream without building a map.
+
+// GroupBy groups all elements by key (map iteration order is unspecified).
+func GroupBy[T any, K comparable](s []T, keyFn func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, v := range s {
+		k := keyFn(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// GroupConsecutive groups adjacent elements sharing the same key, in order.
+func GroupConsecutive[T any, K comparable](s []T, keyFn func(T) K) []Pair[K, []T] {
+	var groups []Pair[K, []T]
+	for _, v := range s {
+		k := keyFn(v)
+		if n := len(groups); n > 0 && groups[n-1].Key == k {
+			groups[n-1].Value = append(groups[n-1].Value, v)
+			continue
+		}
+		groups = append(groups, Pair[K, []T]{Key: k, Value: []T{v}})
+	}
+	return groups
+}
+
+// FlattenMapValues concatenates every value slice — the inverse of GroupBy.
+// Order ACROSS keys follows map iteration and is unspecified (it differs
+// run to run); order within each key's slice is preserved. Sort the result,
+// or iterate sorted keys yourself, if order matters.
+func FlattenMapValues[K comparable, V any](m map[K][]V) []V {
+	n := 0
+	for _, vs := range m {
+		n += len(vs)
+	}
+	result := make([]V, 0, n)
+	for _, vs := range m {
+		result = append(result, vs...)
+	}
+	return result
+}
+
+// ── RUN-LENGTH ENCODING — GroupConsecutive, keeping only the counts ─────────
+// [a a b c c c] ⇄ [(a, 2) (b, 1) (c, 3)]. Pays off on long runs (bitmaps,
+// sensor values that rarely change); on data with no repeats every element
+// becomes a pair and the "compressed" form is larger.
+
+// RunLengthEncode collapses each run of equal neighbours into (value, count).
+func RunLengthEncode[T comparable](s []T) []Pair[T, int] {
+	runs := []Pair[T, int]{}
+	for _, v := range s {
+		if n := len(runs); n > 0 && runs[n-1].Key == v {
+			runs[n-1].Value++
+			continue
+		}
+		runs = append(runs, Pair[T, int]{Key: v, Value: 1})
+	}
+	return runs
+}
+
+// RunLengthDecode expands (value, count) pairs; counts <= 0 contribute nothing.
+func RunLengthDecode[T any](runs []Pair[T, int]) []T {
+	n := 0
+	for _, r := range runs {
+		n += max(r.Value, 0)
+	}
+	result := make([]T, 0, n)
+	for _, r := range runs {
+		for i := 0; i < r.Value; i++ {
+			result = append(result, r.Key)
+		}
+	}
+	return result
+}
+
+// ── ZIP — pairing parallel slices ─────────────────────────────────────────────
+// Zip, ZipWith and ZipToMap all truncate to the shorter input: an element
+// with no partner is dropped rather than paired with a zero value.
+
+// Zip pairs keys[i] with values[i].
+func Zip[K, V any](keys []K, values []V) []Pair[K, V] {
+	n := min(len(keys), len(values))
+	result := make([]Pair[K, V], n)
+	for i := 0; i < n; i++ {
+		result[i] = Pair[K, V]{Key: keys[i], Value: values[i]}
+	}
+	return result
+}
+
+// ZipWith combines a[i] and b[i] with fn — Zip without the intermediate Pair.
+func ZipWith[A, B, C any](a []A, b []B, fn func(A, B) C) []C {
+	n := min(len(a), len(b))
+	result := make([]C, n)
+	for i := 0; i < n; i++ {
+		result[i] = fn(a[i], b[i])
+	}
+	return result
+}
+
+// ZipAll reports whether pred holds for every pair (a[i], b[i]).
+// No pairs → true: there is no pair for which it fails.
+func ZipAll[A, B any](a []A, b []B, pred func(A, B) bool) bool {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if !pred(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ZipAny reports whether pred holds for at least one pair; false when empty.
+func ZipAny[A, B any](a []A, b []B, pred func(A, B) bool) bool {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if pred(a[i], b[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ZipToMap builds a lookup from two parallel slices.
+// Duplicate keys are last-write-wins: the value at the highest index is kept.
+func ZipToMap[K comparable, V any](keys []K, values []V) map[K]V {
+	n := min(len(keys), len(values))
+	m := make(map[K]V, n)
+	for i := 0; i < n; i++ {
+		m[keys[i]] = values[i]
+	}
+	return m
+}
+
+// ── PAIRWISE — overlapping neighbours ─────────────────────────────────────────
+// [a b c] → [(a, b) (b, c)]: Zip(s, s[1:]), spelled out. The building block
+// for deltas, "is it increasing?" checks and edge lists of a path.
+// Fewer than two elements → an empty, non-nil slice.
+
+func Pairwise[T any](s []T) []Pair[T, T] {
+	if len(s) < 2 {
+		return []Pair[T, T]{}
+	}
+	return Zip(s, s[1:])
+}
+
+// ── TOMAP / INDEX — one slice, derived keys ────────────────────────────────────
+// ZipToMap needs two parallel slices; ToMap projects both key and value out
+// of each element. Index is the everyday case: "users by ID".
+// Duplicate keys are last-write-wins, like ZipToMap.
+
+func ToMap[T any, K comparable, V any](s []T, keyFn func(T) K, valFn func(T) V) map[K]V {
+	m := make(map[K]V, len(s))
+	for _, v := range s {
+		m[keyFn(v)] = valFn(v)
+	}
+	return m
+}
+
+func Index[T any, K comparable](s []T, keyFn func(T) K) map[K]T {
+	return ToMap(s, keyFn, func(v T) T { return v })
+}
+
+// ── INTERSPERSE — strings.Join for any slice ──────────────────────────────────
+// Always returns a new slice (never aliases s), including for 0 or 1 elements.
+
+func Intersperse[T any](s []T, sep T) []T {
+	if len(s) < 2 {
+		return append([]T{}, s...)
+	}
+	result := make([]T, 0, 2*len(s)-1)
+	for i, v := range s {
+		if i > 0 {
+			result = append(result, sep)
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+// ── INTERLEAVE — round-robin merge of several slices ─────────────────────────
+// Takes one element from each input in turn; an input that runs out simply
+// drops out of the rotation:
+//
+//   Interleave([1 2 3], [4 5]) → [1 4 2 5 3]
+//
+// Fair merging: no single source can monopolize the front of the result.
+// Like Intersperse, always returns a new slice — a single input is copied.
+
+func Interleave[T any](inputs ...[]T) []T {
+	total, longest := 0, 0
+	for _, in := range inputs {
+		total += len(in)
+		longest = max(longest, len(in))
+	}
+	result := make([]T, 0, total)
+	for i := 0; i < longest; i++ {
+		for _, in := range inputs {
+			if i < len(in) {
+				result = append(result, in[i])
+			}
+		}
+	}
+	return result
+}
+
+// ── BUCKET — distribute by index function ─────────────────────────────────────
+// Partition splits in two; Bucket splits into n by an index function, e.g.
+// hash(key) % n for sharding. idxFn may return something outside [0, n) —
+// OutOfRange decides whether such elements are dropped or pinned to an edge.
+
+type OutOfRange int
+
+const (
+	SkipOutOfRange  OutOfRange = iota // drop the element
+	ClampOutOfRange                   // <0 → bucket 0, >=n → bucket n-1
+)
+
+// Bucket distributes s into n buckets, skipping out-of-range indices.
+func Bucket[T any](s []T, n int, idxFn func(T) int) [][]T {
+	return BucketMode(s, n, idxFn, SkipOutOfRange)
+}
+
+// BucketMode is Bucket with an explicit out-of-range policy.
+// Always returns exactly n buckets (n <= 0 → nil); empty buckets are nil.
+func BucketMode[T any](s []T, n int, idxFn func(T) int, mode OutOfRange) [][]T {
+	if n <= 0 {
+		return nil
+	}
+	buckets := make([][]T, n)
+	for _, v := range s {
+		i := idxFn(v)
+		if i < 0 || i >= n {
+			if mode == SkipOutOfRange {
+				continue
+			}
+			i = max(0, min(i, n-1))
+		}
+		buckets[i] = append(buckets[i], v)
+	}
+	return buckets
+}
+
+// ── SPLIT N — exactly n near-equal parts ──────────────────────────────────────
+// Three ways to cut a slice:
+//   Chunk(s, size)   fixed part SIZE, part count varies     (05_generic_functions)
+//   Bucket(s, n, fn) parts chosen by an index function      (above)
+//   SplitN(s, n)     fixed part COUNT, sizes differ by <= 1  (here)
+//
+// SplitN is the one for "give each of my n workers a fair share". The first
+// len(s)%n parts get the extra element: 10 into 3 → sizes 4, 3, 3.
+//
+// Design choice: the result ALWAYS has n parts, even when n > len(s) — the
+// surplus parts are empty. That way parts[i] is always worker i's share and
+// callers never index out of range. n <= 0 returns nil.
+//
+// Parts are contiguous sub-slices of s (no copying), capped with a full slice
+// expression so appending to one part can't overwrite the next.
+
+// SplitN divides s into n contiguous parts whose lengths differ by at most one.
+func SplitN[T any](s []T, n int) [][]T {
+	if n <= 0 {
+		return nil
+	}
+	parts := make([][]T, n)
+	base, extra := len(s)/n, len(s)%n
+	start := 0
+	for i := range parts {
+		size := base
+		if i < extra {
+			size++
+		}
+		parts[i] = s[start : start+size : start+size]
+		start += size
+	}
+	return parts
+}
+
+// ── SPLIT AT — two halves around an index ────────────────────────────────────
+// idx is clamped, never panics: idx <= 0 → left empty, idx >= len → right
+// empty. Both halves ALIAS s (no copy): writing left[0] writes s[0]. As with
+// SplitN, left is capped at idx so append(left, x) reallocates instead of
+// overwriting right[0].
+
+// SplitAt returns s[:idx] and s[idx:] with idx clamped to [0, len(s)].
+func SplitAt[T any](s []T, idx int) (left, right []T) {
+	idx = min(max(idx, 0), len(s))
+	return s[:idx:idx], s[idx:]
+}
+
+// ── CARTESIAN PRODUCT — every combination, one from each input ───────────────
+// Works like an odometer: the LAST input spins fastest, which yields
+// lexicographic order by input position:
+//
+//   CartesianProduct([a b], [1 2 3]) → [a 1] [a 2] [a 3] [b 1] [b 2] [b 3]
+//
+// SIZE GROWS MULTIPLICATIVELY: len(s₁)·len(s₂)·…·len(sₖ). Ten inputs of ten
+// elements is 10¹⁰ combinations — no machine will hold that. Fine for test
+// matrices (3 OSes × 4 Go versions × 2 flags = 24); for anything bigger,
+// iterate instead of materializing.
+//
+// Any empty input makes the product empty. With NO inputs the result is one
+// empty combination ([[]]), the mathematical identity for products.
+//
+// The size itself can overflow int — 64 inputs of two elements is 2⁶⁴,
+// which wraps to 0 and would look like a valid empty product. Each
+// multiplication is checked, and a count that doesn't fit in int PANICS
+// with "product too large": it could never be allocated anyway.
+
+// CartesianProduct returns all combinations taking one element from each input.
+func CartesianProduct[T any](sets ...[]T) [][]T {
+	for _, s := range sets {
+		if len(s) == 0 {
+			return [][]T{} // checked first: an empty input wins over any overflow
+		}
+	}
+	total := 1
+	for _, s := range sets {
+		if total > math.MaxInt/len(s) {
+			panic(fmt.Sprintf("CartesianProduct: product too large (%d inputs overflow int)", len(sets)))
+		}
+		total *= len(s)
+	}
+	result := make([][]T, 0, total)
+	idx := make([]int, len(sets)) // the odometer
+	for {
+		combo := make([]T, len(sets))
+		for i, s := range sets {
+			combo[i] = s[idx[i]]
+		}
+		result = append(result, combo)
+
+		// Advance: bump the last digit, carrying leftwards.
+		pos := len(sets) - 1
+		for pos >= 0 {
+			idx[pos]++
+			if idx[pos] < len(sets[pos]) {
+				break
+			}
+			idx[pos] = 0
+			pos--
+		}
+		if pos < 0 {
+			return result // odometer rolled over: every combination emitted
+		}
+	}
+}
+
+// ── FILL / CLEAR — overwrite in place ──────────────────────────────────────────
+// Both keep len and cap and reuse the backing array — no allocation.
+//
+// Clear matters for GC: s = s[:0] makes a slice LOOK empty, but every
+// pointer still sitting in the backing array keeps its target alive.
+// Zeroing the elements first lets the collector reclaim them — do it before
+// putting a buffer back in a sync.Pool or reusing it for the next request.
+// (Go 1.21's builtin clear(s) does the same for slices.)
+
+// Fill sets every element of s to v.
+func Fill[T any](s []T, v T) {
+	for i := range s {
+		s[i] = v
+	}
+}
+
+// Clear sets every element of s to T's zero value.
+func Clear[T any](s []T) {
+	var zero T
+	Fill(s, zero)
+}
+
+// ── GENERATORS — fixtures and initial values ─────────────────────────────────
+// RangeInts follows Python's range: start is included, end is EXCLUDED, and
+// the sign of step picks the direction.
+//
+//   RangeInts(0, 10, 3)  → [0 3 6 9]
+//   RangeInts(5, 0, -2)  → [5 3 1]
+//   RangeInts(3, 3, 1)   → []            (empty, not an error)
+//
+// step == 0 would loop forever, and a step pointing away from end (0→10 by
+// -1) can never arrive — both are errors rather than a silent empty slice.
+//
+// Near the int limits, `v += step` wraps around: RangeInts(MaxInt-1, MaxInt,
+// 2) would go negative and never stop. So the count is computed first in
+// uint, where end-start always fits, and each value is start + i*step. Every
+// result lies between start and end; even if i*step alone overflows, Go's
+// wrapping arithmetic still lands start + i*step on the right value.
+//
+// A valid range can still be far too big to allocate — RangeInts(0, MaxInt,
+// 1) would make() panic, and much smaller ones exhaust memory. Ranges longer
+// than MaxRangeLen return ErrRangeTooLarge instead.
+
+// MaxRangeLen is the longest slice RangeInts will build (128 MiB of int64).
+const MaxRangeLen = 1 << 24
+
+var (
+	ErrZeroStep       = errors.New("range: step must not be zero")
+	ErrRangeDirection = errors.New("range: step direction does not reach end")
+	ErrRangeTooLarge  = fmt.Errorf("range: more than %d values", MaxRangeLen)
+)
+
+// RangeInts returns start, start+step, ... up to but not including end.
+func RangeInts(start, end, step int) ([]int, error) {
+	if step == 0 {
+		return nil, ErrZeroStep
+	}
+	if (step > 0 && start > end) || (step < 0 && start < end) {
+		return nil, ErrRangeDirection
+	}
+	// Distance and step magnitude as uint: no overflow even for MinInt..MaxInt.
+	span, stride := uint(end)-uint(start), uint(step)
+	if step < 0 {
+		span, stride = uint(start)-uint(end), -uint(step)
+	}
+	count := span / stride
+	if span%stride != 0 {
+		count++ // ceil: a partial last step still yields a value
+	}
+	if count > MaxRangeLen {
+		return nil, ErrRangeTooLarge
+	}
+	n := int(count)
+	result := make([]int, n)
+	for i := 0; i < n; i++ {
+		result[i] = start + i*step
+	}
+	return result, nil
+}
+
+// RepeatSlice returns n copies of v. n <= 0 gives an empty, non-nil slice.
+// For pointer/slice/map T the copies share what v points to.
+func RepeatSlice[T any](v T, n int) []T {
+	result := make([]T, max(n, 0))
+	for i := range result {
+		result[i] = v
+	}
+	return result
+}
+
+// Cycle repeats s end to end until the result has exactly length elements:
+// Cycle([a b], 5) → [a b a b a]; a length shorter than s truncates it.
+// length <= 0 gives an empty, non-nil slice. An empty s with a positive
+// length PANICS: there is nothing to repeat, and returning fewer elements
+// than asked for would break the caller's "exactly length" assumption.
+func Cycle[T any](s []T, length int) []T {
+	result := make([]T, max(length, 0))
+	if len(result) > 0 && len(s) == 0 {
+		panic("Cycle: empty input with positive length")
+	}
+	for i := range result {
+		result[i] = s[i%len(s)]
+	}
+	return result
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Slice Utilities")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── FlattenAny ────────────────────────────────────────────────────────
+	fmt.Println("\n── FlattenAny ──")
+	fmt.Printf("  [][]int{{1,2},{3},{}}:        %v\n", FlattenAny([][]int{{1, 2}, {3}, {}}))
+	fmt.Printf("  []any{1, []any{2, 3}}:        %v\n", FlattenAny([]any{1, []any{2, 3}}))
+	fmt.Printf("  deep []any{1,[]any{[]any{2}}}: %v\n", FlattenAny([]any{1, []any{[]any{2}}}))
+	fmt.Printf("  scalar 42:                    %v\n", FlattenAny(42))
+	fmt.Printf("  string \"go\" (leaf):           %q\n", FlattenAny("go"))
+	fmt.Printf("  map stays whole:              %v\n", FlattenAny([]any{map[string]int{"a": 1}, [2]int{7, 8}}))
+
+	// ── GroupBy vs GroupConsecutive ───────────────────────────────────────
+	fmt.Println("\n── GroupBy vs GroupConsecutive ──")
+	identity := func(n int) int { return n }
+	nums := []int{1, 1, 2, 1}
+	byKey := GroupBy(nums, identity)
+	fmt.Printf("  GroupBy([1,1,2,1]):          1→%v 2→%v (%d groups)\n", byKey[1], byKey[2], len(byKey))
+	runs := GroupConsecutive(nums, identity)
+	fmt.Printf("  GroupConsecutive([1,1,2,1]): %v (%d groups)\n", runs, len(runs))
+
+	logLevels := []string{"INFO", "INFO", "WARN", "WARN", "WARN", "INFO"}
+	for _, g := range GroupConsecutive(logLevels, func(s string) string { return s }) {
+		fmt.Printf("  run: %s ×%d\n", g.Key, len(g.Value))
+	}
+
+	// ── FlattenMapValues ──────────────────────────────────────────────────
+	fmt.Println("\n── FlattenMapValues ──")
+	words := []string{"go", "rust", "c", "zig", "java", "d"}
+	byLen := GroupBy(words, func(w string) int { return len(w) })
+	flat := FlattenMapValues(byLen)
+	sumLens := 0
+	for _, ws := range byLen {
+		sumLens += len(ws)
+	}
+	fmt.Printf("  FlattenMapValues(GroupBy(len)): %d elements (sum of groups %d, input %d)\n",
+		len(flat), sumLens, len(words))
+	slices.Sort(flat) // order across keys varies run to run
+	fmt.Printf("  sorted: %v\n", flat)
+
+	// ── RunLengthEncode / Decode ──────────────────────────────────────────
+	fmt.Println("\n── RunLengthEncode / RunLengthDecode ──")
+	raw := []string{"a", "a", "b", "c", "c", "c"}
+	rle := RunLengthEncode(raw)
+	fmt.Printf("  %v → %v\n", raw, rle)
+	back := RunLengthDecode(rle)
+	fmt.Printf("  decode → %v, round-trip equal: %v\n", back, slices.Equal(back, raw))
+	pixels := []int{0, 0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	fmt.Printf("  16 pixels → %d runs: %v\n", len(RunLengthEncode(pixels)), RunLengthEncode(pixels))
+	fmt.Printf("  empty: %v → %v\n", RunLengthEncode([]int{}), RunLengthDecode([]Pair[int, int]{}))
+
+	// ── Zip / ZipWith / ZipToMap ──────────────────────────────────────────
+	fmt.Println("\n── Zip / ZipWith / ZipToMap ──")
+	names := []string{"alice", "bob", "carol"}
+	ages := []int{30, 25}
+	fmt.Printf("  Zip(3 names, 2 ages):      %v\n", Zip(names, ages))
+	fmt.Printf("  ZipToMap(3 names, 2 ages): %v\n", ZipToMap(names, ages))
+	fmt.Printf("  duplicate key \"a\":        %v (last write wins)\n",
+		ZipToMap([]string{"a", "b", "a"}, []int{1, 2, 3}))
+	prices := []int{250, 1200, 99, 40}
+	qty := []int{4, 1, 10}
+	totals := ZipWith(prices, qty, func(p, q int) int { return p * q })
+	fmt.Printf("  ZipWith(4 prices, 3 qtys, ×): %v\n", totals)
+	fmt.Printf("  ZipWith(names, ages, fmt):    %q\n",
+		ZipWith(names, ages, func(n string, a int) string { return fmt.Sprintf("%s:%d", n, a) }))
+	caps := []int{300, 1500, 100}
+	under := func(p, c int) bool { return p <= c }
+	over := func(p, c int) bool { return p > c }
+	fmt.Printf("  caps %v: ZipAll(≤)=%v ZipAny(>)=%v (4th price has no cap → ignored)\n",
+		caps, ZipAll(prices, caps, under), ZipAny(prices, caps, over))
+	caps[1] = 1000
+	fmt.Printf("  caps %v: ZipAll(≤)=%v ZipAny(>)=%v\n", caps, ZipAll(prices, caps, under), ZipAny(prices, caps, over))
+	fmt.Printf("  empty: ZipAll=%v ZipAny=%v\n", ZipAll([]int{}, caps, under), ZipAny([]int{}, caps, under))
+
+	// ── Pairwise ──────────────────────────────────────────────────────────
+	fmt.Println("\n── Pairwise ──")
+	readings := []int{100, 104, 101, 110}
+	pairs := Pairwise(readings)
+	deltas := make([]int, len(pairs))
+	for i, p := range pairs {
+		deltas[i] = p.Value - p.Key
+	}
+	fmt.Printf("  readings %v → pairs %v → deltas %v\n", readings, pairs, deltas)
+	fmt.Printf("  len 0: %v  len 1: %v\n", Pairwise([]int{}), Pairwise([]int{7}))
+
+	// ── ToMap / Index ─────────────────────────────────────────────────────
+	fmt.Println("\n── ToMap / Index ──")
+	type User struct {
+		ID   int
+		Name string
+	}
+	users := []User{{1, "alice"}, {2, "bob"}, {1, "alice-v2"}} // ID 1 twice
+	byID := Index(users, func(u User) int { return u.ID })
+	fmt.Printf("  Index by ID: %v (len %d; ID 1 = last write)\n", byID, len(byID))
+	idByName := ToMap(users, func(u User) string { return u.Name }, func(u User) int { return u.ID })
+	fmt.Printf("  ToMap name→ID: %v\n", idByName)
+
+	// ── Intersperse ───────────────────────────────────────────────────────
+	fmt.Println("\n── Intersperse ──")
+	fmt.Printf("  [1 2 3] sep 0: %v\n", Intersperse([]int{1, 2, 3}, 0))
+	fmt.Printf("  single [7]:    %v\n", Intersperse([]int{7}, 0))
+	fmt.Printf("  empty:         %v\n", Intersperse([]int{}, 0))
+	fmt.Printf("  tokens:        %q\n", Intersperse([]string{"SELECT", "a", "b"}, ","))
+
+	// ── Interleave ────────────────────────────────────────────────────────
+	fmt.Println("\n── Interleave ──")
+	fmt.Printf("  [1 2 3] + [4 5]:          %v\n", Interleave([]int{1, 2, 3}, []int{4, 5}))
+	fmt.Printf("  three feeds, unequal:     %q\n",
+		Interleave([]string{"a1", "a2", "a3", "a4"}, []string{"b1"}, []string{"c1", "c2"}))
+	single := []int{7, 8}
+	copied := Interleave(single)
+	copied[0] = 0
+	fmt.Printf("  single input: %v, original untouched: %v\n", copied, single)
+	fmt.Printf("  no inputs:    %v\n", Interleave[int]())
+
+	// ── Bucket ────────────────────────────────────────────────────────────
+	fmt.Println("\n── Bucket / BucketMode ──")
+	ids := []int{0, 5, 7, 12, 3, 9, 17}
+	fmt.Printf("  by id%%3:          %v\n", Bucket(ids, 3, func(id int) int { return id % 3 }))
+	byFives := func(id int) int { return id/5 - 1 } // 0→-1, 17→2: out of range on both sides
+	fmt.Printf("  id/5-1, skip:     %v\n", BucketMode(ids, 2, byFives, SkipOutOfRange))
+	fmt.Printf("  id/5-1, clamp:    %v\n", BucketMode(ids, 2, byFives, ClampOutOfRange))
+
+	// ── SplitN ────────────────────────────────────────────────────────────
+	fmt.Println("\n── SplitN ──")
+	ten := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	fmt.Printf("  10 into 3: %v\n", SplitN(ten, 3))
+	fmt.Printf("  10 into 5: %v\n", SplitN(ten, 5))
+	fmt.Printf("  3 into 5:  %v (always n parts; surplus empty)\n", SplitN([]string{"a", "b", "c"}, 5))
+	parts := SplitN(ten, 2)
+	parts[0] = append(parts[0], 99) // capped: reallocates instead of clobbering parts[1]
+	fmt.Printf("  append to part 0 leaves part 1 intact: %v\n", parts[1])
+
+	// ── SplitAt ───────────────────────────────────────────────────────────
+	fmt.Println("\n── SplitAt ──")
+	five := []int{1, 2, 3, 4, 5}
+	for _, idx := range []int{2, 0, 5, -3, 99} {
+		l, r := SplitAt(five, idx)
+		fmt.Printf("  SplitAt(%v, %3d) → %v | %v\n", five, idx, l, r)
+	}
+	l, r := SplitAt(five, 2)
+	r[0] = 30         // aliases five[2]
+	l = append(l, 77) // capped: does NOT overwrite r[0]
+	fmt.Printf("  after r[0]=30, append(l, 77): five=%v l=%v r=%v\n", five, l, r)
+
+	// ── CartesianProduct ──────────────────────────────────────────────────
+	fmt.Println("\n── CartesianProduct ──")
+	matrix := CartesianProduct([]string{"linux", "darwin"}, []string{"1.21", "1.22", "1.23"}, []string{"race", "norace"})
+	fmt.Printf("  2×3×2 = %d combinations:\n", len(matrix))
+	for _, combo := range matrix[:4] {
+		fmt.Printf("    %v\n", combo)
+	}
+	fmt.Printf("    ... last: %v\n", matrix[len(matrix)-1])
+	fmt.Printf("  with an empty input: %v\n", CartesianProduct([]int{1, 2}, []int{}, []int{3}))
+	fmt.Printf("  single input:        %v\n", CartesianProduct([]int{1, 2, 3}))
+	fmt.Printf("  no inputs:           %v (len %d)\n", CartesianProduct[int](), len(CartesianProduct[int]()))
+	func() {
+		defer func() { fmt.Printf("  64 inputs of 2:      panic: %v\n", recover()) }()
+		bits := make([][]bool, 64)
+		for i := range bits {
+			bits[i] = []bool{false, true}
+		}
+		CartesianProduct(bits...) // 2⁶⁴ would wrap to 0
+	}()
+
+	// ── Fill / Clear ──────────────────────────────────────────────────────
+	fmt.Println("\n── Fill / Clear ──")
+	grid := make([]rune, 5)
+	Fill(grid, '.')
+	fmt.Printf("  Fill(make([]rune, 5), '.') → %q\n", string(grid))
+
+	type Session struct{ User string }
+	pooled := []*Session{{"alice"}, {"bob"}, {"carol"}}
+	Clear(pooled)
+	allNil := true
+	for _, p := range pooled {
+		allNil = allNil && p == nil
+	}
+	fmt.Printf("  Clear([]*Session) → %v, all nil=%v, len=%d (unchanged)\n", pooled, allNil, len(pooled))
+
+	// ── RangeInts / RepeatSlice ───────────────────────────────────────────
+	fmt.Println("\n── RangeInts / RepeatSlice ──")
+	for _, r := range [][3]int{{0, 10, 3}, {1, 6, 1}, {5, 0, -2}, {10, -10, -5}, {3, 3, 1}, {0, 5, 0}, {0, 10, -1}} {
+		seq, err := RangeInts(r[0], r[1], r[2])
+		fmt.Printf("  RangeInts(%d, %d, %d) → %v err=%v\n", r[0], r[1], r[2], seq, err)
+	}
+	// Near the int limits: no wraparound, no runaway loop.
+	for _, r := range [][3]int{{math.MaxInt - 1, math.MaxInt, 2}, {math.MinInt + 2, math.MinInt, -1}} {
+		seq, err := RangeInts(r[0], r[1], r[2])
+		fmt.Printf("  RangeInts(%d, %d, %d) → %v err=%v\n", r[0], r[1], r[2], seq, err)
+	}
+	_, err := RangeInts(math.MinInt, math.MaxInt, 1)
+	fmt.Printf("  RangeInts(MinInt, MaxInt, 1) → err=%v\n", err)
+	_, err = RangeInts(0, math.MaxInt, 1) // valid, but would panic in make
+	fmt.Printf("  RangeInts(0, MaxInt, 1)      → err=%v\n", err)
+	fmt.Printf("  RepeatSlice(\"-\", 5) → %q\n", RepeatSlice("-", 5))
+	zero := RepeatSlice(42, 0)
+	fmt.Printf("  RepeatSlice(42, 0)  → %v (len %d, nil=%v)\n", zero, len(zero), zero == nil)
+
+	// ── Cycle ─────────────────────────────────────────────────────────────
+	fmt.Println("\n── Cycle ──")
+	fmt.Printf("  Cycle([a b], 5)   → %v\n", Cycle([]string{"a", "b"}, 5))
+	fmt.Printf("  Cycle([1 2 3], 2) → %v\n", Cycle([]int{1, 2, 3}, 2))
+	hosts := []string{"eu", "us"}
+	jobIDs := []int{101, 102, 103, 104, 105}
+	fmt.Printf("  assign hosts to jobs: %v\n", Zip(jobIDs, Cycle(hosts, len(jobIDs))))
+	fmt.Printf("  Cycle([], 0) → %v\n", Cycle([]int{}, 0))
+	func() {
+		defer func() { fmt.Printf("  Cycle([], 3) panics: %v\n", recover()) }()
+		Cycle([]int{}, 3)
+	}()
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  FlattenAny: reflect-based flatten for unknown nesting depth")
+	fmt.Println("  GroupBy: global groups in a map; GroupConsecutive: ordered runs")
+	fmt.Println("  FlattenMapValues: un-GroupBy; order across keys unspecified")
+	fmt.Println("  RunLengthEncode / Decode: runs ⇄ (value, count) pairs, lossless")
+	fmt.Println("  Zip / ZipWith / ZipToMap: truncate to the shorter slice; map is last-write-wins")
+	fmt.Println("  ZipAll / ZipAny: pairwise predicate; empty → true / false")
+	fmt.Println("  Pairwise: overlapping (s[i], s[i+1]); < 2 elements → empty")
+	fmt.Println("  ToMap / Index: key (and value) projections; last-write-wins")
+	fmt.Println("  Intersperse: separator between elements; always a fresh copy")
+	fmt.Println("  Interleave: round-robin merge; exhausted inputs drop out")
+	fmt.Println("  Bucket / BucketMode: n buckets by index fn; skip or clamp out-of-range")
+	fmt.Println("  SplitN: exactly n contiguous parts, sizes differ by at most one")
+	fmt.Println("  SplitAt: clamped index; halves alias s, left is capped")
+	fmt.Println("  CartesianProduct: odometer order; size is the product of lengths")
+	fmt.Println("  Fill / Clear: overwrite in place; Clear drops pointers for the GC")
+	fmt.Println("  RangeInts / RepeatSlice: [start, end) by step; n copies of v")
+	fmt.Println("  Cycle: repeat s to exactly length; empty s with length > 0 panics")
+}
@@ -0,0 +1,653 @@
+// FILE: 09_generics/09_generic_collections/09_generic_collections.go
+// TOPIC: Generic Collections — Counter, List, and other reusable containers
+//
+// Run: go run 09_generics/09_generic_collections/09_generic_collections.go
+
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Pair[K, V any] struct {
+	Key   K
+	Value V
+}
+
+func (p Pair[K, V]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.Key, p.Value)
+}
+
+// ── COUNTER[T] — frequency counting ───────────────────────────────────────────
+// map[T]int does the counting; the extra `order` slice remembers first-seen
+// order. T is only comparable (not ordered), so we can't break ties by value —
+// instead TopN uses a STABLE sort over first-seen order, which makes ties
+// deterministic: the key seen first wins.
+
+type Counter[T comparable] struct {
+	mu     sync.Mutex
+	counts map[T]int
+	order  []T
+	total  int
+}
+
+func NewCounter[T comparable]() *Counter[T] {
+	return &Counter[T]{counts: make(map[T]int)}
+}
+
+func (c *Counter[T]) Inc(key T) { c.Add(key, 1) }
+
+func (c *Counter[T]) Add(key T, n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, seen := c.counts[key]; !seen {
+		c.order = append(c.order, key)
+	}
+	c.counts[key] += n
+	c.total += n
+}
+
+func (c *Counter[T]) Get(key T) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[key]
+}
+
+func (c *Counter[T]) Total() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total
+}
+
+// TopN returns up to n keys ordered by count descending.
+// Ties keep first-seen order. Fewer than n distinct keys → all of them.
+func (c *Counter[T]) TopN(n int) []Pair[T, int] {
+	c.mu.Lock()
+	pairs := make([]Pair[T, int], len(c.order))
+	for i, k := range c.order {
+		pairs[i] = Pair[T, int]{Key: k, Value: c.counts[k]}
+	}
+	c.mu.Unlock()
+
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Value > pairs[j].Value })
+	if n < len(pairs) {
+		pairs = pairs[:max(n, 0)]
+	}
+	return pairs
+}
+
+// ── SORT BY VALUE — map → leaderboard ─────────────────────────────────────────
+// A plain map has no first-seen order to fall back on like Counter's, and
+// its iteration order is random — so ties MUST be broken by key, or the same
+// map prints differently on every run.
+//
+// Note the constraint: SortByValueDesc takes K cmp.Ordered, not just
+// comparable, because "break ties by key" needs keys that can be ordered.
+// Struct or pointer keys go through SortByValueDescFunc with their own key
+// comparison; passing nil there leaves tied entries in random map order.
+
+// SortByValueDesc returns m's entries by value descending, ties by key ascending.
+func SortByValueDesc[K cmp.Ordered, V cmp.Ordered](m map[K]V) []Pair[K, V] {
+	return SortByValueDescFunc(m, cmp.Compare[K])
+}
+
+// SortByValueDescFunc is SortByValueDesc for any comparable key: ties are
+// ordered by keyCmp (negative = a first), or left unordered if it is nil.
+func SortByValueDescFunc[K comparable, V cmp.Ordered](m map[K]V, keyCmp func(a, b K) int) []Pair[K, V] {
+	pairs := make([]Pair[K, V], 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, Pair[K, V]{Key: k, Value: v})
+	}
+	slices.SortFunc(pairs, func(a, b Pair[K, V]) int {
+		if c := cmp.Compare(b.Value, a.Value); c != 0 || keyCmp == nil {
+			return c
+		}
+		return keyCmp(a.Key, b.Key)
+	})
+	return pairs
+}
+
+// ── LIST[T] — doubly-linked, O(1) at both ends ───────────────────────────────
+// A slice makes PopFront O(n) (shift everything left) or leaks the head of the
+// backing array. A doubly-linked list makes every end operation O(1), and
+// unlinking a known node is O(1) too — which is what an LRU cache needs.
+//
+// Like container/list, a sentinel `root` node closes the ring: root.next is
+// the front, root.prev is the back, and an empty list points root at itself.
+// No nil checks for "first" or "last" node anywhere.
+
+type listNode[T any] struct {
+	prev, next *listNode[T]
+	val        T
+}
+
+type List[T any] struct {
+	root listNode[T]
+	len  int
+}
+
+func NewList[T any]() *List[T] {
+	l := &List[T]{}
+	l.lazyInit()
+	return l
+}
+
+// lazyInit makes the zero List usable, as with container/list.
+func (l *List[T]) lazyInit() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+	}
+}
+
+func (l *List[T]) Len() int { return l.len }
+
+func (l *List[T]) insertAfter(at *listNode[T], v T) *listNode[T] {
+	n := &listNode[T]{prev: at, next: at.next, val: v}
+	at.next.prev = n
+	at.next = n
+	l.len++
+	return n
+}
+
+func (l *List[T]) remove(n *listNode[T]) T {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.prev, n.next = nil, nil // help GC, catch reuse
+	l.len--
+	return n.val
+}
+
+func (l *List[T]) PushFront(v T) { l.lazyInit(); l.insertAfter(&l.root, v) }
+func (l *List[T]) PushBack(v T)  { l.lazyInit(); l.insertAfter(l.root.prev, v) }
+
+// moveToFront relinks n (already in l) as the first node.
+func (l *List[T]) moveToFront(n *listNode[T]) {
+	if l.root.next == n {
+		return
+	}
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.prev, n.next = &l.root, l.root.next
+	l.root.next.prev = n
+	l.root.next = n
+}
+
+// PopFront removes and returns the first element; ok is false when empty.
+func (l *List[T]) PopFront() (v T, ok bool) {
+	if l.len == 0 {
+		return v, false
+	}
+	return l.remove(l.root.next), true
+}
+
+// PopBack removes and returns the last element; ok is false when empty.
+func (l *List[T]) PopBack() (v T, ok bool) {
+	if l.len == 0 {
+		return v, false
+	}
+	return l.remove(l.root.prev), true
+}
+
+// Range visits elements front to back until fn returns false.
+func (l *List[T]) Range(fn func(T) bool) {
+	l.lazyInit()
+	for n := l.root.next; n != &l.root; n = n.next {
+		if !fn(n.val) {
+			return
+		}
+	}
+}
+
+func (l *List[T]) String() string {
+	parts := make([]string, 0, l.len)
+	l.Range(func(v T) bool {
+		parts = append(parts, fmt.Sprint(v))
+		return true
+	})
+	return "[" + strings.Join(parts, " ⇄ ") + "]"
+}
+
+// ── HEAP[T] — binary min-heap with Fix and Remove ────────────────────────────
+// The same algorithms as container/heap, but typed: no heap.Interface to
+// implement, no `any` boxing on Push/Pop. `less` decides the order — the
+// element for which less(x, everything) holds sits at the root.
+//
+// Beyond Push/Pop, Fix(i) restores order after the element at i changed
+// priority and Remove(i) deletes an arbitrary element — e.g. cancelling a
+// scheduled task. Both take an INDEX, and indices move on every mutation:
+// an index you read before a Push, Pop, Fix or Remove may now belong to a
+// different element. To keep one valid, pass onIndex to NewHeap: it is
+// called with (element, newIndex) whenever an element moves, and with
+// (element, -1) when it leaves the heap. Store that index in the element
+// (T is then usually a pointer) and it is always current.
+
+type Heap[T any] struct {
+	items   []T
+	less    func(a, b T) bool
+	onIndex func(v T, i int) // optional
+}
+
+func NewHeap[T any](less func(a, b T) bool, onIndex func(v T, i int)) *Heap[T] {
+	return &Heap[T]{less: less, onIndex: onIndex}
+}
+
+func (h *Heap[T]) Len() int { return len(h.items) }
+
+// Peek returns the root without removing it; ok is false when empty.
+func (h *Heap[T]) Peek() (v T, ok bool) {
+	if len(h.items) == 0 {
+		return v, false
+	}
+	return h.items[0], true
+}
+
+func (h *Heap[T]) Push(v T) {
+	h.items = append(h.items, v)
+	h.moved(len(h.items) - 1)
+	h.up(len(h.items) - 1)
+}
+
+// Pop removes and returns the root; ok is false when empty.
+func (h *Heap[T]) Pop() (v T, ok bool) {
+	if len(h.items) == 0 {
+		return v, false
+	}
+	return h.Remove(0), true
+}
+
+// Remove deletes and returns the element at index i. Panics if i is out of
+// range, like a slice index.
+func (h *Heap[T]) Remove(i int) T {
+	n := len(h.items) - 1
+	if i != n {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	v := h.items[n]
+	var zero T
+	h.items[n] = zero // don't keep the removed element reachable
+	h.items = h.items[:n]
+	if h.onIndex != nil {
+		h.onIndex(v, -1)
+	}
+	return v
+}
+
+// Fix re-establishes heap order after the element at index i changed.
+// Cheaper than Remove followed by Push.
+func (h *Heap[T]) Fix(i int) {
+	if !h.down(i, len(h.items)) {
+		h.up(i)
+	}
+}
+
+func (h *Heap[T]) moved(i int) {
+	if h.onIndex != nil {
+		h.onIndex(h.items[i], i)
+	}
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.moved(i)
+	h.moved(j)
+}
+
+func (h *Heap[T]) up(j int) {
+	for j > 0 {
+		parent := (j - 1) / 2
+		if !h.less(h.items[j], h.items[parent]) {
+			break
+		}
+		h.swap(parent, j)
+		j = parent
+	}
+}
+
+// down sifts the element at i0 toward the leaves within items[:n] and
+// reports whether it moved.
+func (h *Heap[T]) down(i0, n int) bool {
+	i := i0
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && h.less(h.items[right], h.items[child]) {
+			child = right
+		}
+		if !h.less(h.items[child], h.items[i]) {
+			break
+		}
+		h.swap(i, child)
+		i = child
+	}
+	return i > i0
+}
+
+// ── LRU CACHE[K, V] — map + List, everything O(1) ────────────────────────────
+// The map finds a key's list node; the list keeps recency order, most
+// recently used at the front. A hit moves the node to the front; a Put into a
+// full cache evicts from the back. Safe for concurrent use.
+
+type lruEntry[K comparable, V any] struct {
+	key K
+	val V
+}
+
+type LRUCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    List[lruEntry[K, V]]
+	nodes    map[K]*listNode[lruEntry[K, V]]
+}
+
+// NewLRUCache panics if capacity < 1: a cache that can hold nothing is a bug.
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	if capacity < 1 {
+		panic("NewLRUCache: capacity must be >= 1")
+	}
+	c := &LRUCache[K, V]{capacity: capacity, nodes: make(map[K]*listNode[lruEntry[K, V]], capacity)}
+	c.order.lazyInit()
+	return c
+}
+
+func (c *LRUCache[K, V]) Get(key K) (v V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, ok := c.nodes[key]
+	if !ok {
+		return v, false
+	}
+	c.order.moveToFront(n)
+	return n.val.val, true
+}
+
+func (c *LRUCache[K, V]) Put(key K, val V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n, ok := c.nodes[key]; ok {
+		n.val.val = val
+		c.order.moveToFront(n)
+		return
+	}
+	if c.order.Len() == c.capacity {
+		oldest, _ := c.order.PopBack()
+		delete(c.nodes, oldest.key)
+	}
+	c.nodes[key] = c.order.insertAfter(&c.order.root, lruEntry[K, V]{key: key, val: val})
+}
+
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// ── GROUP[K, V] — single-flight: one call per key at a time ──────────────────
+// When 100 requests miss the cache for the same key at once, only the first
+// should hit the database; the other 99 wait and share its result. Same idea
+// as golang.org/x/sync/singleflight, typed. Once a call finishes the key is
+// forgotten, so the next Do runs fn again — Group dedups, it doesn't cache.
+
+type groupCall[V any] struct {
+	wg  sync.WaitGroup
+	val V
+	err error
+}
+
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*groupCall[V]
+}
+
+// Do runs fn for key, or waits for the in-flight call for key and returns its result.
+func (g *Group[K, V]) Do(key K, fn func() (V, error)) (V, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*groupCall[V])
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+	c := &groupCall[V]{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.val, c.err = fn()
+	c.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	return c.val, c.err
+}
+
+// ── TIERED CACHE[K, V] — LRU in front of a slow loader ───────────────────────
+//
+//   Get ──▶ L1 LRU ──hit──▶ value
+//              │ miss
+//              ▼
+//           Group.Do(key) ──▶ Loader (DB, HTTP, disk) ──▶ Put into L1
+//
+// Concurrent misses for one key share a single Loader call. Loader errors
+// are returned to every waiter but NOT cached, so the next Get retries.
+
+type TieredCache[K comparable, V any] struct {
+	l1     *LRUCache[K, V]
+	loader func(K) (V, error)
+	group  Group[K, V]
+}
+
+func NewTieredCache[K comparable, V any](capacity int, loader func(K) (V, error)) *TieredCache[K, V] {
+	return &TieredCache[K, V]{l1: NewLRUCache[K, V](capacity), loader: loader}
+}
+
+func (c *TieredCache[K, V]) Get(key K) (V, error) {
+	if v, ok := c.l1.Get(key); ok {
+		return v, nil
+	}
+	return c.group.Do(key, func() (V, error) {
+		// A flight that finished between our miss and Do has already filled L1.
+		if v, ok := c.l1.Get(key); ok {
+			return v, nil
+		}
+		v, err := c.loader(key)
+		if err != nil {
+			return v, err
+		}
+		c.l1.Put(key, v)
+		return v, nil
+	})
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Generic Collections")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── Counter[T] ────────────────────────────────────────────────────────
+	fmt.Println("\n── Counter[T] ──")
+	words := NewCounter[string]()
+	for _, w := range strings.Fields("the quick fox and the lazy dog and the cat") {
+		words.Inc(w)
+	}
+	fmt.Printf("  Get(\"the\")=%d Get(\"and\")=%d Get(\"zebra\")=%d Total=%d\n",
+		words.Get("the"), words.Get("and"), words.Get("zebra"), words.Total())
+	fmt.Printf("  TopN(3): %v\n", words.TopN(3))
+	// quick/fox/lazy/dog/cat all tie at 1 — first-seen order decides:
+	fmt.Printf("  TopN(4): %v (tie → first seen)\n", words.TopN(4))
+
+	status := NewCounter[int]()
+	status.Add(200, 950)
+	status.Add(500, 12)
+	fmt.Printf("  TopN(5) with 2 distinct keys: %v\n", status.TopN(5))
+
+	// ── SortByValueDesc ───────────────────────────────────────────────────
+	fmt.Println("\n── SortByValueDesc ──")
+	scores := map[string]int{"dana": 88, "ari": 95, "cole": 88, "bo": 95, "eve": 70}
+	fmt.Printf("  leaderboard: %v\n", SortByValueDesc(scores))
+	sameEveryRun := true
+	first := fmt.Sprint(SortByValueDesc(scores))
+	for i := 0; i < 50; i++ {
+		sameEveryRun = sameEveryRun && fmt.Sprint(SortByValueDesc(scores)) == first
+	}
+	fmt.Printf("  ties (95, 88) broken by key; identical over 50 calls: %v\n", sameEveryRun)
+	fmt.Printf("  empty map: %v\n", SortByValueDesc(map[int]float64{}))
+
+	type Region struct{ Cloud, Zone string } // struct key: comparable, not ordered
+	latency := map[Region]int{{"aws", "eu-1"}: 40, {"gcp", "us-2"}: 95, {"aws", "us-1"}: 95}
+	byRegion := func(a, b Region) int {
+		return cmp.Or(cmp.Compare(a.Cloud, b.Cloud), cmp.Compare(a.Zone, b.Zone))
+	}
+	fmt.Printf("  struct keys via SortByValueDescFunc: %v\n", SortByValueDescFunc(latency, byRegion))
+
+	// ── List[T] ───────────────────────────────────────────────────────────
+	fmt.Println("\n── List[T] ──")
+	l := NewList[int]()
+	fmt.Printf("  new: %v len=%d\n", l, l.Len())
+	l.PushBack(2)
+	l.PushBack(3)
+	l.PushFront(1)
+	l.PushFront(0)
+	fmt.Printf("  PushBack 2,3 then PushFront 1,0: %v len=%d\n", l, l.Len())
+	f, _ := l.PopFront()
+	b, _ := l.PopBack()
+	fmt.Printf("  PopFront=%d PopBack=%d → %v\n", f, b, l)
+
+	var firstBig int
+	l.PushBack(10)
+	l.PushBack(20)
+	l.Range(func(v int) bool {
+		firstBig = v
+		return v < 10 // stop at the first value >= 10
+	})
+	fmt.Printf("  Range with early stop, first >= 10: %d\n", firstBig)
+
+	for l.Len() > 0 {
+		l.PopBack()
+	}
+	v, ok := l.PopFront()
+	fmt.Printf("  drained: %v len=%d, PopFront on empty=(%d, %v)\n", l, l.Len(), v, ok)
+	l.PushBack(42)
+	fmt.Printf("  reuse after empty: %v\n", l)
+
+	// ── Heap[T] ───────────────────────────────────────────────────────────
+	fmt.Println("\n── Heap[T] ──")
+	type job struct {
+		name     string
+		priority int
+		index    int // kept current by onIndex; -1 once out of the heap
+	}
+	jobs := NewHeap(
+		func(a, b *job) bool { return a.priority < b.priority },
+		func(j *job, i int) { j.index = i },
+	)
+	byName := map[string]*job{}
+	for i, name := range []string{"backup", "email", "report", "cleanup", "deploy"} {
+		j := &job{name: name, priority: []int{50, 10, 30, 40, 20}[i]}
+		byName[name] = j
+		jobs.Push(j)
+	}
+	top, _ := jobs.Peek()
+	fmt.Printf("  pushed 5 jobs; Peek: %s (priority %d)\n", top.name, top.priority)
+
+	report := byName["report"]
+	removed := jobs.Remove(report.index)
+	fmt.Printf("  cancel report via its tracked index: removed %s, index now %d\n", removed.name, report.index)
+
+	backup := byName["backup"]
+	backup.priority = 5
+	oldIndex := backup.index
+	jobs.Fix(backup.index)
+	fmt.Printf("  backup priority 50 → 5, Fix(%d): backup moved to index %d (root)\n", oldIndex, backup.index)
+
+	var order []string
+	for jobs.Len() > 0 {
+		j, _ := jobs.Pop()
+		order = append(order, fmt.Sprintf("%s:%d", j.name, j.priority))
+	}
+	fmt.Printf("  Pop order: %v\n", order)
+	_, ok = jobs.Pop()
+	fmt.Printf("  Pop on empty: ok=%v\n", ok)
+
+	// ── LRUCache[K, V] ────────────────────────────────────────────────────
+	fmt.Println("\n── LRUCache[K, V] ──")
+	lru := NewLRUCache[string, int](2)
+	lru.Put("a", 1)
+	lru.Put("b", 2)
+	lru.Get("a")    // a is now most recent
+	lru.Put("c", 3) // evicts b, the least recently used
+	_, hasA := lru.Get("a")
+	_, hasB := lru.Get("b")
+	_, hasC := lru.Get("c")
+	fmt.Printf("  cap 2: Put a, b; Get a; Put c → a=%v b=%v c=%v len=%d\n", hasA, hasB, hasC, lru.Len())
+
+	// ── TieredCache[K, V] ─────────────────────────────────────────────────
+	fmt.Println("\n── TieredCache[K, V] (LRU + single-flight loader) ──")
+	var (
+		loadsMu sync.Mutex
+		loads   = map[string]int{} // loader calls per key
+	)
+	countLoad := func(key string) int {
+		loadsMu.Lock()
+		defer loadsMu.Unlock()
+		loads[key]++
+		return loads[key]
+	}
+	loadsOf := func(key string) int {
+		loadsMu.Lock()
+		defer loadsMu.Unlock()
+		return loads[key]
+	}
+	users := NewTieredCache(100, func(id string) (string, error) {
+		n := countLoad(id)
+		time.Sleep(20 * time.Millisecond) // slow database
+		if id == "flaky" && n == 1 {
+			return "", errors.New("db timeout")
+		}
+		return "user:" + id, nil
+	})
+
+	v1, _ := users.Get("42")
+	v2, _ := users.Get("42")
+	fmt.Printf("  cold Get: %q, warm Get: %q — loader calls: %d\n", v1, v2, loadsOf("42"))
+
+	var tcWg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		tcWg.Add(1)
+		go func() {
+			defer tcWg.Done()
+			users.Get("7")
+		}()
+	}
+	tcWg.Wait()
+	fmt.Printf("  20 concurrent cold Gets for \"7\": loader calls: %d\n", loadsOf("7"))
+
+	_, errFlaky := users.Get("flaky")
+	vFlaky, errFlaky2 := users.Get("flaky")
+	fmt.Printf("  loader error not cached: first err=%v, retry=%q err=%v\n", errFlaky, vFlaky, errFlaky2)
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  Counter[T]: Inc/Add/Get/Total, TopN with stable first-seen tie-break")
+	fmt.Println("  SortByValueDesc: map entries by value desc, key asc on ties")
+	fmt.Println("  SortByValueDescFunc: same for comparable keys, caller orders ties")
+	fmt.Println("  List[T]: sentinel ring, O(1) Push/Pop at both ends, Range early stop")
+	fmt.Println("  LRUCache[K,V]: map + List, O(1) Get/Put, evicts least recently used")
+	fmt.Println("  Group[K,V]: single-flight — concurrent calls for a key share one fn run")
+	fmt.Println("  TieredCache[K,V]: LRU L1 + Group-deduped loader; errors not cached")
+	fmt.Println("  Heap[T]: typed container/heap; Fix/Remove by index, onIndex tracks moves")
+}
@@ -0,0 +1,827 @@
+// FILE: 09_generics/10_slice_algorithms/10_slice_algorithms.go
+// TOPIC: Slice Algorithms — dedupe, compact, compare, search, diff (set + ordered)
+//
+// Run: go run 09_generics/10_slice_algorithms/10_slice_algorithms.go
+
+package main
+
+import (
+	"cmp"
+	"container/heap"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// ── UNIQUE — map-based, any order (same as 03_constraints) ────────────────────
+
+// Unique removes duplicates anywhere in the slice, keeping first occurrences.
+// O(n) time but O(n) extra memory for the seen-set.
+func Unique[T comparable](s []T) []T {
+	seen := make(map[T]struct{}, len(s))
+	result := make([]T, 0, len(s))
+	for _, v := range s {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// ── APPEND IF MISSING — keep a small slice set-like ──────────────────────────
+// For a handful of tags or feature flags, a slice is simpler than a map and
+// keeps insertion order. The price: every call scans the whole slice, O(n),
+// so building an n-element set this way is O(n²). Past a few dozen elements
+// switch to map[T]struct{} (or Unique once at the end).
+//
+// As with append, always use the returned slice.
+
+// AppendIfMissing appends v unless it is already present.
+func AppendIfMissing[T comparable](s []T, v T) []T {
+	if slices.Contains(s, v) {
+		return s
+	}
+	return append(s, v)
+}
+
+// AppendIfMissingFunc appends v unless eq(existing, v) holds for some element.
+func AppendIfMissingFunc[T any](s []T, v T, eq func(a, b T) bool) []T {
+	if slices.ContainsFunc(s, func(e T) bool { return eq(e, v) }) {
+		return s
+	}
+	return append(s, v)
+}
+
+// ── IS SORTED — check a precondition in O(n) ─────────────────────────────────
+// Sorted means NON-DECREASING: no element is less than the one before it, so
+// equal neighbours are fine ([1 2 2 3] is sorted). Empty and one-element
+// slices are trivially sorted.
+//
+// IsSorted uses cmp.Less, which orders NaN before every other float — the
+// same order slices.Sort produces — so a float slice just sorted by the
+// stdlib always passes. IsSortedBy takes the same `less` as sort.Slice, for
+// custom orderings and non-ordered types.
+
+// IsSorted reports whether s is in ascending order.
+func IsSorted[T cmp.Ordered](s []T) bool {
+	return IsSortedBy(s, cmp.Less[T])
+}
+
+// IsSortedBy reports whether s is sorted according to less.
+func IsSortedBy[T any](s []T, less func(a, b T) bool) bool {
+	for i := 1; i < len(s); i++ {
+		if less(s[i], s[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ── DEDUP SORTED — in place, no map ───────────────────────────────────────────
+// PRECONDITION: s is sorted (or at least, equal elements are adjacent).
+// Then every duplicate sits right next to its original, so one pass with a
+// write index removes them: no map, no allocation, O(n).
+//
+// The result reuses s's backing array — s itself is overwritten, so use the
+// returned slice and don't keep reading the old one. On unsorted input the
+// result is still well-defined but only adjacent duplicates are removed.
+
+// DedupSorted removes duplicates from a sorted slice in place.
+func DedupSorted[T comparable](s []T) []T {
+	return DedupSortedFunc(s, func(a, b T) bool { return a == b })
+}
+
+// DedupSortedFunc is DedupSorted with a custom equality function.
+func DedupSortedFunc[T any](s []T, eq func(a, b T) bool) []T {
+	return CompactFunc(s, eq) // on sorted input, adjacent == all duplicates
+}
+
+// ── COMPACT — collapse runs of equal neighbours ──────────────────────────────
+// The algorithm behind DedupSorted, without the sortedness promise (mirrors
+// stdlib slices.Compact). It removes CONSECUTIVE repeats only:
+//
+//   Compact([1 1 2 1]) → [1 2 1]    runs collapsed, the later 1 survives
+//   Unique([1 1 2 1])  → [1 2]      every repeat removed
+//
+// Useful after a sort, or for squashing repeated log lines / sensor readings.
+// Like DedupSorted it works in place on s's backing array.
+
+// Compact replaces each run of equal elements with a single copy.
+func Compact[T comparable](s []T) []T {
+	return CompactFunc(s, func(a, b T) bool { return a == b })
+}
+
+// CompactFunc is Compact with a custom equality function.
+func CompactFunc[T any](s []T, eq func(a, b T) bool) []T {
+	if len(s) < 2 {
+		return s
+	}
+	w := 1 // s[:w] is the compacted prefix
+	for r := 1; r < len(s); r++ {
+		if !eq(s[r], s[w-1]) {
+			s[w] = s[r]
+			w++
+		}
+	}
+	return s[:w]
+}
+
+// ── EQUALITY ──────────────────────────────────────────────────────────────────
+// Slices aren't comparable with ==, so equality is a loop.
+// nil vs empty: both compare by length, so nil and []T{} are EQUAL — at the
+// top level and for inner rows of a 2-D slice. This matches stdlib
+// slices.Equal; if you need to tell them apart, check `== nil` explicitly.
+
+// SliceEqual reports whether a and b have the same elements in the same order.
+func SliceEqual[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SliceEqual2D compares two matrices row by row (rows may be ragged).
+func SliceEqual2D[T comparable](a, b [][]T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !SliceEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ── SAME ELEMENTS — equality ignoring order ──────────────────────────────────
+// Compares MULTISETS: [a a b] vs [a b b] is false even though both contain
+// {a, b}. One map counts up over a and down over b; any non-zero count means
+// a mismatch. O(n) time, O(distinct keys) memory.
+//
+// SameElementsBy projects each element to a comparable key first, so it works
+// on structs that aren't comparable themselves (slices/maps inside) — only
+// the keys have to be.
+
+// SameElements reports whether a and b contain the same elements with the
+// same multiplicities, in any order.
+func SameElements[T comparable](a, b []T) bool {
+	return SameElementsBy(a, b, func(v T) T { return v })
+}
+
+// SameElementsBy is SameElements comparing keyFn(element) instead of elements.
+func SameElementsBy[T any, K comparable](a, b []T, keyFn func(T) K) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[K]int, len(a))
+	for _, v := range a {
+		counts[keyFn(v)]++
+	}
+	for _, v := range b {
+		k := keyFn(v)
+		if counts[k] == 0 {
+			return false
+		}
+		counts[k]--
+	}
+	return true // equal lengths + no underflow → every count is back to 0
+}
+
+// ── COMMON PREFIX — longest shared start ─────────────────────────────────────
+// Split file paths into segments and the common prefix is the shared parent
+// directory: [srv app logs] + [srv app cache] → [srv app]. With more than two
+// inputs, fold pairwise — the prefix can only shrink, so stop once it's empty.
+//
+// The result is a sub-slice of the FIRST input (no copy), with its capacity
+// clipped so an append on the result can't overwrite that input's data.
+// Any empty input (or no inputs at all) gives an empty prefix.
+
+// CommonPrefix returns the longest prefix shared by a and b.
+func CommonPrefix[T comparable](a, b []T) []T {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return a[:n:n]
+}
+
+// CommonPrefixAll returns the longest prefix shared by every slice.
+func CommonPrefixAll[T comparable](inputs ...[]T) []T {
+	if len(inputs) == 0 {
+		return nil
+	}
+	prefix := inputs[0]
+	for _, s := range inputs[1:] {
+		if len(prefix) == 0 {
+			break
+		}
+		prefix = CommonPrefix(prefix, s)
+	}
+	return prefix[:len(prefix):len(prefix)]
+}
+
+// ── TRANSPOSE — rows become columns ──────────────────────────────────────────
+// m[i][j] → t[j][i]. Only defined for RECTANGULAR input: with ragged rows
+// some t[j][i] would have no source, so Transpose reports which row breaks
+// the shape instead of guessing (padding with zero values hides bugs).
+//
+// Edge cases: no rows, or rows that are all empty, transpose to an empty
+// (non-nil) matrix; a single row becomes a single column and vice versa.
+// The result is freshly allocated — one backing array for all rows.
+
+// Transpose returns the transpose of a rectangular matrix.
+func Transpose[T any](m [][]T) ([][]T, error) {
+	if len(m) == 0 {
+		return [][]T{}, nil
+	}
+	cols := len(m[0])
+	for i, row := range m {
+		if len(row) != cols {
+			return nil, fmt.Errorf("transpose: ragged matrix: row %d has %d columns, row 0 has %d", i, len(row), cols)
+		}
+	}
+	backing := make([]T, cols*len(m))
+	t := make([][]T, cols)
+	for j := range t {
+		t[j] = backing[j*len(m) : (j+1)*len(m) : (j+1)*len(m)]
+		for i := range m {
+			t[j][i] = m[i][j]
+		}
+	}
+	return t, nil
+}
+
+// ── DIFF — reconcile desired vs actual ───────────────────────────────────────
+// Set semantics: each slice is treated as a SET, so duplicates collapse and
+// multiplicity is ignored ([a a] vs [a] → no change). Results keep the order
+// of first appearance in their source slice, which makes output stable.
+
+// Diff returns elements only in new (added) and only in old (removed).
+func Diff[T comparable](old, new []T) (added, removed []T) {
+	inOld := make(map[T]struct{}, len(old))
+	for _, v := range old {
+		inOld[v] = struct{}{}
+	}
+	inNew := make(map[T]struct{}, len(new))
+	for _, v := range new {
+		inNew[v] = struct{}{}
+	}
+	for _, v := range Unique(new) {
+		if _, ok := inOld[v]; !ok {
+			added = append(added, v)
+		}
+	}
+	for _, v := range Unique(old) {
+		if _, ok := inNew[v]; !ok {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+// ── ORDERED DIFF — edit script via longest common subsequence ────────────────
+// Diff above answers "which items?"; OrderedDiff answers "which steps?" —
+// the keep/delete/insert script that turns old into new, as list
+// reconciliation and line-based text diffs need. Elements in the longest
+// common subsequence are kept; everything else in old is deleted and
+// everything else in new is inserted. Where both happen at one spot the
+// deletes come first, the way `diff -u` prints - lines before + lines.
+//
+// lcs[i][j] is the LCS length of old[i:] and new[j:]: O(n·m) time and space.
+// Fine for UI lists and short files; use Myers' algorithm for big inputs.
+
+type DiffKind int
+
+const (
+	DiffKeep DiffKind = iota
+	DiffDelete
+	DiffInsert
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffKeep:
+		return "keep"
+	case DiffDelete:
+		return "delete"
+	case DiffInsert:
+		return "insert"
+	}
+	return fmt.Sprintf("DiffKind(%d)", int(k))
+}
+
+type DiffOp[T any] struct {
+	Kind  DiffKind
+	Value T
+}
+
+func OrderedDiff[T comparable](old, new []T) []DiffOp[T] {
+	n, m := len(old), len(new)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if old[i] == new[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]DiffOp[T], 0, n+m-lcs[0][0])
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case old[i] == new[j]:
+			ops = append(ops, DiffOp[T]{DiffKeep, old[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, DiffOp[T]{DiffDelete, old[i]})
+			i++
+		default:
+			ops = append(ops, DiffOp[T]{DiffInsert, new[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, DiffOp[T]{DiffDelete, old[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, DiffOp[T]{DiffInsert, new[j]})
+	}
+	return ops
+}
+
+// ApplyDiff replays ops against old. Keeps and deletes must match old in
+// order, so a script made for a different input is an error, not garbage.
+func ApplyDiff[T comparable](old []T, ops []DiffOp[T]) ([]T, error) {
+	var out []T
+	i := 0
+	for k, op := range ops {
+		if op.Kind == DiffInsert {
+			out = append(out, op.Value)
+			continue
+		}
+		if i >= len(old) || old[i] != op.Value {
+			return nil, fmt.Errorf("op %d (%s %v) does not match old[%d]", k, op.Kind, op.Value, i)
+		}
+		if op.Kind == DiffKeep {
+			out = append(out, op.Value)
+		}
+		i++
+	}
+	if i != len(old) {
+		return nil, fmt.Errorf("ops consumed %d of %d old elements", i, len(old))
+	}
+	return out, nil
+}
+
+// ── FIND FIRST / LAST — the element, not the index ───────────────────────────
+// IndexFunc answers "where?"; most callers then immediately do s[i].
+// These return the element itself plus an ok flag (zero value, false if none).
+
+func FindFirst[T any](s []T, pred func(T) bool) (T, bool) {
+	for _, v := range s {
+		if pred(v) {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// FindLast scans from the end, so it stops at the last match immediately.
+func FindLast[T any](s []T, pred func(T) bool) (T, bool) {
+	for i := len(s) - 1; i >= 0; i-- {
+		if pred(s[i]) {
+			return s[i], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// ── FIRST / LAST — guarded s[0] and s[len(s)-1] ─────────────────────────────
+// Replaces the `if len(s) > 0 { x = s[0] }` guard. The Or variants take the
+// value to use for an empty slice.
+
+func First[T any](s []T) (T, bool) {
+	if len(s) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s[0], true
+}
+
+func Last[T any](s []T) (T, bool) {
+	if len(s) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s[len(s)-1], true
+}
+
+func FirstOr[T any](s []T, def T) T {
+	if v, ok := First(s); ok {
+		return v
+	}
+	return def
+}
+
+func LastOr[T any](s []T, def T) T {
+	if v, ok := Last(s); ok {
+		return v
+	}
+	return def
+}
+
+// ── MIN MAX — both extremes in one pass ──────────────────────────────────────
+// Calling min() then max() walks the slice twice. One loop can track both.
+// Empty input has no extremes: ok=false and zero values.
+// (Named results min/max shadow the builtins inside this function only.)
+//
+// NaN: every comparison with NaN is false, so a plain loop would keep a NaN
+// found at index 0 and silently skip one found later. Like slices.Min and
+// slices.Max, any NaN makes BOTH results NaN, wherever it sits. v != v is
+// true only for NaN, so non-float T pay one extra compare.
+
+func MinMax[T cmp.Ordered](s []T) (min, max T, ok bool) {
+	if len(s) == 0 {
+		return min, max, false
+	}
+	min, max = s[0], s[0]
+	for _, v := range s {
+		if v != v { // NaN
+			return v, v, true
+		}
+		if v < min {
+			min = v
+		} else if v > max {
+			max = v
+		}
+	}
+	return min, max, true
+}
+
+// ── INDEX OF SUBSLICE — strings.Index for slices ─────────────────────────────
+// Empty needle → 0, the same convention as strings.Index("abc", ""): the empty
+// sequence occurs at the very start of anything.
+//
+// Naive scan: O(n·m) worst case (e.g. haystack aaaa…ab, needle aaab).
+// Fine for short needles; for long, repetitive patterns KMP brings it to
+// O(n+m) by never re-reading haystack elements.
+
+func IndexOfSubslice[T comparable](haystack, needle []T) int {
+	if len(needle) == 0 {
+		return 0
+	}
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		if SliceEqual(haystack[i:i+len(needle)], needle) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ── INDEX ALL — every match, not just the first ──────────────────────────────
+// IndexOf (03_constraints) and slices.Index stop at the first hit. IndexAll
+// returns every position in ascending order. No matches → an EMPTY, non-nil
+// slice, so JSON encodes it as [] rather than null.
+
+// IndexAll returns the indices of every element equal to target.
+func IndexAll[T comparable](s []T, target T) []int {
+	return IndexAllFunc(s, func(v T) bool { return v == target })
+}
+
+// IndexAllFunc returns the indices of every element for which pred holds.
+func IndexAllFunc[T any](s []T, pred func(T) bool) []int {
+	result := []int{}
+	for i, v := range s {
+		if pred(v) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// ── TOP K — bounded min-heap, O(n log k) ─────────────────────────────────────
+// Sorting everything to keep 10 of a million items is O(n log n). Instead keep
+// a min-heap of at most k items: its root is the smallest of the current top
+// k, so each new element only needs comparing against the root and, when it
+// is larger, replaces it with one O(log k) sift.
+
+// minHeap adapts a slice and a less func to container/heap.
+type minHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *minHeap[T]) Len() int           { return len(h.items) }
+func (h *minHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *minHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *minHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+func (h *minHeap[T]) Pop() any {
+	n := len(h.items) - 1
+	v := h.items[n]
+	h.items = h.items[:n]
+	return v
+}
+
+// TopK returns the k largest elements of s by less, sorted descending.
+// k >= len(s) returns all of s sorted descending; k <= 0 returns an empty
+// slice. s is not modified.
+func TopK[T any](s []T, k int, less func(a, b T) bool) []T {
+	k = min(max(k, 0), len(s))
+	h := &minHeap[T]{items: make([]T, 0, k), less: less}
+	for _, v := range s {
+		switch {
+		case h.Len() < k:
+			heap.Push(h, v)
+		case k > 0 && less(h.items[0], v):
+			h.items[0] = v
+			heap.Fix(h, 0)
+		}
+	}
+	// Popping a min-heap yields ascending order; fill from the back.
+	result := make([]T, k)
+	for i := k - 1; i >= 0; i-- {
+		result[i] = heap.Pop(h).(T)
+	}
+	return result
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Slice Algorithms")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── AppendIfMissing ───────────────────────────────────────────────────
+	fmt.Println("\n── AppendIfMissing / AppendIfMissingFunc ──")
+	tags := []string{"go", "backend"}
+	tags = AppendIfMissing(tags, "api")
+	fmt.Printf("  add \"api\":     %v\n", tags)
+	tags = AppendIfMissing(tags, "go")
+	fmt.Printf("  add \"go\" again: %v (no-op)\n", tags)
+	tags = AppendIfMissingFunc(tags, "API", strings.EqualFold)
+	fmt.Printf("  add \"API\" case-insensitively: %v (no-op)\n", tags)
+	tags = AppendIfMissingFunc(tags, "Infra", strings.EqualFold)
+	fmt.Printf("  add \"Infra\" case-insensitively: %v\n", tags)
+
+	// ── IsSorted / IsSortedBy ─────────────────────────────────────────────
+	fmt.Println("\n── IsSorted / IsSortedBy ──")
+	for _, c := range [][]int{{1, 2, 3}, {1, 3, 2}, {2, 2, 2}, {1, 2, 2, 5}, {}} {
+		fmt.Printf("  %-10v sorted: %v\n", fmt.Sprint(c), IsSorted(c))
+	}
+	fmt.Printf("  %q sorted: %v\n", []string{"apple", "banana", "cherry"}, IsSorted([]string{"apple", "banana", "cherry"}))
+
+	type Release struct {
+		Name  string
+		Major int
+	}
+	releases := []Release{{"v3", 3}, {"v2-lts", 2}, {"v2", 2}, {"v1", 1}}
+	newestFirst := func(a, b Release) bool { return a.Major > b.Major }
+	fmt.Printf("  releases newest-first: %v (equal Major neighbours allowed)\n", IsSortedBy(releases, newestFirst))
+	fmt.Printf("  same releases oldest-first: %v\n",
+		IsSortedBy(releases, func(a, b Release) bool { return a.Major < b.Major }))
+
+	// Guard a sorted-input helper with it:
+	if in := []int{3, 1, 3}; !IsSorted(in) {
+		fmt.Printf("  %v is not sorted → DedupSorted would miss duplicates: %v\n", in, DedupSorted(slices.Clone(in)))
+	}
+
+	// ── DedupSorted ───────────────────────────────────────────────────────
+	fmt.Println("\n── DedupSorted / DedupSortedFunc ──")
+	sorted := []int{1, 1, 2, 3, 3, 3, 7}
+	backing := &sorted[0]
+	deduped := DedupSorted(sorted)
+	fmt.Printf("  [1 1 2 3 3 3 7] → %v (same backing array: %v)\n", deduped, &deduped[0] == backing)
+	fmt.Printf("  all duplicates [5 5 5 5] → %v\n", DedupSorted([]int{5, 5, 5, 5}))
+	fmt.Printf("  already unique [1 2 3]   → %v\n", DedupSorted([]int{1, 2, 3}))
+	fmt.Printf("  empty                    → %v\n", DedupSorted([]int{}))
+
+	type Entry struct {
+		Day   string
+		Count int
+	}
+	byDay := []Entry{{"mon", 3}, {"mon", 5}, {"tue", 1}, {"wed", 2}, {"wed", 9}}
+	fmt.Printf("  DedupSortedFunc by Day   → %v\n",
+		DedupSortedFunc(byDay, func(a, b Entry) bool { return a.Day == b.Day }))
+
+	fmt.Printf("  Unique (map, any order)  → %v\n", Unique([]int{3, 1, 3, 2, 1}))
+
+	// ── Compact / CompactFunc ─────────────────────────────────────────────
+	fmt.Println("\n── Compact / CompactFunc ──")
+	fmt.Printf("  Compact([1 1 2 1]) → %v (consecutive only)\n", Compact([]int{1, 1, 2, 1}))
+	fmt.Printf("  Unique([1 1 2 1])  → %v (global)\n", Unique([]int{1, 1, 2, 1}))
+	logLines := []string{"conn reset", "conn reset", "conn reset", "retrying", "conn reset"}
+	fmt.Printf("  log lines → %q\n", Compact(logLines))
+	fmt.Printf("  CompactFunc case-insensitive → %v\n",
+		CompactFunc([]string{"Go", "GO", "go", "Rust", "rust", "Go"}, strings.EqualFold))
+
+	// ── SliceEqual / SliceEqual2D ─────────────────────────────────────────
+	fmt.Println("\n── SliceEqual / SliceEqual2D ──")
+	m := [][]int{{1, 2, 3}, {4, 5, 6}}
+	fmt.Printf("  equal matrices:          %v\n", SliceEqual2D(m, [][]int{{1, 2, 3}, {4, 5, 6}}))
+	fmt.Printf("  outer length differs:    %v\n", SliceEqual2D(m, [][]int{{1, 2, 3}}))
+	fmt.Printf("  inner length differs:    %v\n", SliceEqual2D(m, [][]int{{1, 2, 3}, {4, 5}}))
+	fmt.Printf("  one element differs:     %v\n", SliceEqual2D(m, [][]int{{1, 2, 3}, {4, 0, 6}}))
+	fmt.Printf("  nil row vs empty row:    %v (treated as equal)\n", SliceEqual2D([][]int{nil}, [][]int{{}}))
+
+	// ── SameElements / SameElementsBy ─────────────────────────────────────
+	fmt.Println("\n── SameElements / SameElementsBy ──")
+	fmt.Printf("  [1 2 3] vs [3 1 2]:       %v\n", SameElements([]int{1, 2, 3}, []int{3, 1, 2}))
+	fmt.Printf("  [1 1 2] vs [1 2 2]:       %v (multiplicities differ)\n", SameElements([]int{1, 1, 2}, []int{1, 2, 2}))
+
+	type Order struct {
+		ID    string
+		Items []string // makes Order non-comparable
+	}
+	got := []Order{{"b", []string{"x"}}, {"a", nil}, {"a", []string{"y"}}}
+	want := []Order{{"a", nil}, {"b", nil}, {"a", nil}}
+	byID := func(o Order) string { return o.ID }
+	fmt.Printf("  orders by ID [b a a] vs [a b a]: %v\n", SameElementsBy(got, want, byID))
+	fmt.Printf("  orders by ID [b a a] vs [a b b]: %v\n",
+		SameElementsBy(got, []Order{{"a", nil}, {"b", nil}, {"b", nil}}, byID))
+
+	// ── CommonPrefix / CommonPrefixAll ────────────────────────────────────
+	fmt.Println("\n── CommonPrefix / CommonPrefixAll ──")
+	fmt.Printf("  [1 2 3 4] vs [1 2 9]: %v\n", CommonPrefix([]int{1, 2, 3, 4}, []int{1, 2, 9}))
+	fmt.Printf("  [1 2 3] vs [1 2 3]:   %v (full match)\n", CommonPrefix([]int{1, 2, 3}, []int{1, 2, 3}))
+	fmt.Printf("  [1 2] vs [9 2]:       %v (none in common)\n", CommonPrefix([]int{1, 2}, []int{9, 2}))
+	fmt.Printf("  [1 2] vs []:          %v\n", CommonPrefix([]int{1, 2}, nil))
+
+	paths := []string{"/srv/app/logs/api.log", "/srv/app/cache/db", "/srv/app/logs/worker.log"}
+	segs := make([][]string, len(paths))
+	for i, p := range paths {
+		segs[i] = strings.Split(strings.Trim(p, "/"), "/")
+	}
+	fmt.Printf("  paths %q\n    common dir: /%s\n", paths, strings.Join(CommonPrefixAll(segs...), "/"))
+	fmt.Printf("  with /etc/hosts added: %q; no inputs: %q\n",
+		CommonPrefixAll(append(segs, []string{"etc", "hosts"})...), CommonPrefixAll[string]())
+
+	src := []int{1, 2, 3}
+	pre := CommonPrefix(src, []int{1, 2, 0})
+	pre = append(pre, 99)
+	fmt.Printf("  append to prefix %v leaves input intact: %v\n", pre, src)
+
+	// ── Transpose ─────────────────────────────────────────────────────────
+	fmt.Println("\n── Transpose ──")
+	for _, mat := range [][][]int{
+		{{1, 2, 3}, {4, 5, 6}}, // 2×3 → 3×2
+		{{1, 2, 3}},            // single row → single column
+		{{1}, {2}, {3}},        // single column → single row
+		{{}, {}},               // all-empty rows
+		{},                     // no rows
+		{{1, 2}, {3}},          // ragged
+	} {
+		t, err := Transpose(mat)
+		fmt.Printf("  %-17s → %v err=%v\n", fmt.Sprint(mat), t, err)
+	}
+
+	// ── Diff ──────────────────────────────────────────────────────────────
+	fmt.Println("\n── Diff (desired vs actual) ──")
+	show := func(label string, old, new []string) {
+		added, removed := Diff(old, new)
+		fmt.Printf("  %-12s added=%q removed=%q\n", label, added, removed)
+	}
+	show("disjoint:", []string{"a", "b"}, []string{"c", "d"})
+	show("identical:", []string{"a", "b"}, []string{"b", "a"})
+	show("overlap:", []string{"web-1", "web-2", "web-3"}, []string{"web-2", "web-3", "web-4"})
+	show("duplicates:", []string{"a", "a", "b"}, []string{"a", "c", "c"})
+
+	// ── OrderedDiff / ApplyDiff ───────────────────────────────────────────
+	fmt.Println("\n── OrderedDiff / ApplyDiff ──")
+	symbol := map[DiffKind]string{DiffKeep: " ", DiffDelete: "-", DiffInsert: "+"}
+	render := func(ops []DiffOp[string]) string {
+		parts := make([]string, len(ops))
+		for i, op := range ops {
+			parts[i] = symbol[op.Kind] + op.Value
+		}
+		return strings.Join(parts, " ")
+	}
+	diffCases := []struct{ old, new []string }{
+		{strings.Split("ABCABBA", ""), strings.Split("CBABAC", "")},
+		{[]string{"home", "docs", "blog"}, []string{"home", "blog", "about"}},
+		{nil, []string{"x", "y"}},
+		{[]string{"x", "y"}, nil},
+		{[]string{"same"}, []string{"same"}},
+	}
+	for _, c := range diffCases {
+		ops := OrderedDiff(c.old, c.new)
+		got, err := ApplyDiff(c.old, ops)
+		fmt.Printf("  %q → %q\n    ops: %s\n    apply == new: %v (err=%v)\n",
+			c.old, c.new, render(ops), slices.Equal(got, c.new), err)
+	}
+	_, err := ApplyDiff([]string{"a", "b"}, OrderedDiff([]string{"a", "c"}, []string{"c"}))
+	fmt.Printf("  script for other input: %v\n", err)
+
+	// ── FindFirst / FindLast ──────────────────────────────────────────────
+	fmt.Println("\n── FindFirst / FindLast ──")
+	temps := []int{12, 18, 25, 9, 31, 22}
+	hot := func(t int) bool { return t > 20 }
+	first, ok1 := FindFirst(temps, hot)
+	last, ok2 := FindLast(temps, hot)
+	fmt.Printf("  first >20: %d (%v), last >20: %d (%v)\n", first, ok1, last, ok2)
+	none, ok := FindFirst(temps, func(t int) bool { return t > 100 })
+	fmt.Printf("  no match: %d, %v\n", none, ok)
+
+	// ── First / Last ──────────────────────────────────────────────────────
+	fmt.Println("\n── First / Last / FirstOr / LastOr ──")
+	f, okF := First(temps)
+	l, okL := Last(temps)
+	fmt.Printf("  First=%d (%v) Last=%d (%v)\n", f, okF, l, okL)
+	var noArgs []string
+	ef, okF := First(noArgs)
+	el, okL := Last(noArgs)
+	fmt.Printf("  empty: First=%q (%v) Last=%q (%v)\n", ef, okF, el, okL)
+	fmt.Printf("  FirstOr(empty, \"default\")=%q  LastOr([a b], \"default\")=%q\n",
+		FirstOr(noArgs, "default"), LastOr([]string{"a", "b"}, "default"))
+
+	// ── MinMax ────────────────────────────────────────────────────────────
+	fmt.Println("\n── MinMax ──")
+	lo, hi, ok := MinMax(temps)
+	fmt.Printf("  MinMax(%v): min=%d max=%d ok=%v\n", temps, lo, hi, ok)
+	fmt.Printf("  agrees with slices.Min/Max: %v\n", lo == slices.Min(temps) && hi == slices.Max(temps))
+	wlo, whi, _ := MinMax([]string{"pear", "apple", "fig"})
+	fmt.Printf("  strings: min=%q max=%q\n", wlo, whi)
+	_, _, ok = MinMax([]float64{})
+	fmt.Printf("  empty: ok=%v\n", ok)
+	for _, fs := range [][]float64{{math.NaN(), 1, 2}, {1, math.NaN(), 2}} {
+		flo, fhi, _ := MinMax(fs)
+		fmt.Printf("  %v: min=%v max=%v (slices.Min/Max: %v %v)\n", fs, flo, fhi, slices.Min(fs), slices.Max(fs))
+	}
+
+	// ── IndexOfSubslice ───────────────────────────────────────────────────
+	fmt.Println("\n── IndexOfSubslice ──")
+	hay := []int{1, 2, 3, 4, 5, 3, 4}
+	for _, needle := range [][]int{{1, 2}, {3, 4}, {5, 3, 4}, {4, 6}, {}, {1, 2, 3, 4, 5, 3, 4, 9}} {
+		fmt.Printf("  needle %-17v → %d\n", fmt.Sprint(needle), IndexOfSubslice(hay, needle))
+	}
+
+	// ── IndexAll / IndexAllFunc ───────────────────────────────────────────
+	fmt.Println("\n── IndexAll / IndexAllFunc ──")
+	words := strings.Fields("the cat saw the dog chase the cat")
+	fmt.Printf("  IndexAll(%q): %v\n", "the", IndexAll(words, "the"))
+	fmt.Printf("  IndexAll(%q): %v\n", "cat", IndexAll(words, "cat"))
+	noMatch := IndexAll(words, "bird")
+	fmt.Printf("  IndexAll(%q): %v nil=%v\n", "bird", noMatch, noMatch == nil)
+	fmt.Printf("  IndexAllFunc(len > 3): %v\n", IndexAllFunc(words, func(w string) bool { return len(w) > 3 }))
+
+	// ── TopK ──────────────────────────────────────────────────────────────
+	fmt.Println("\n── TopK ──")
+	type player struct {
+		name  string
+		score int
+	}
+	players := []player{{"ana", 70}, {"bo", 95}, {"cy", 40}, {"di", 88}, {"ed", 95}, {"fy", 62}}
+	byScore := func(a, b player) bool { return a.score < b.score }
+	fmt.Printf("  top 3 players: %v\n", TopK(players, 3, byScore))
+	fmt.Printf("  k=10 (≥ len): %v\n", TopK([]int{3, 1, 2}, 10, func(a, b int) bool { return a < b }))
+	fmt.Printf("  k=0: %v\n", TopK([]int{3, 1, 2}, 0, func(a, b int) bool { return a < b }))
+
+	// Check against sort-everything-and-slice on random input.
+	rng := rand.New(rand.NewSource(1))
+	big := make([]int, 10_000)
+	for i := range big {
+		big[i] = rng.Intn(1000)
+	}
+	desc := slices.Clone(big)
+	slices.SortFunc(desc, func(a, b int) int { return cmp.Compare(b, a) })
+	agree := true
+	for _, k := range []int{1, 5, 100, 10_000, 20_000} {
+		got := TopK(big, k, func(a, b int) bool { return a < b })
+		agree = agree && slices.Equal(got, desc[:min(k, len(desc))])
+	}
+	fmt.Printf("  matches full sort for k=1,5,100,n,2n on 10k random ints: %v\n", agree)
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  Unique: map-based, works on any order, allocates")
+	fmt.Println("  AppendIfMissing / Func: set-like append, O(n) scan per call")
+	fmt.Println("  IsSorted / IsSortedBy: non-decreasing check, equal neighbours OK")
+	fmt.Println("  DedupSorted: sorted input only, in place, O(n), no map")
+	fmt.Println("  Compact / CompactFunc: collapse adjacent repeats, in place")
+	fmt.Println("  SliceEqual / SliceEqual2D: length + element-wise; nil == empty")
+	fmt.Println("  SameElements / SameElementsBy: order-insensitive multiset compare")
+	fmt.Println("  CommonPrefix / CommonPrefixAll: shared start, sub-slice of the first input")
+	fmt.Println("  Transpose: rectangular only; ragged input is an error, not padded")
+	fmt.Println("  Diff: set-based added/removed, first-appearance order")
+	fmt.Println("  OrderedDiff: LCS edit script (keep/-/+), O(n·m); ApplyDiff replays it")
+	fmt.Println("  FindFirst / FindLast: element + ok; FindLast scans backwards")
+	fmt.Println("  First / Last (+ok), FirstOr / LastOr (+default): no length guards")
+	fmt.Println("  MinMax[T cmp.Ordered]: one pass, ok=false on empty")
+	fmt.Println("  IndexOfSubslice: first match or -1; empty needle → 0")
+	fmt.Println("  IndexAll / IndexAllFunc: every matching index; no match → [] not nil")
+	fmt.Println("  TopK: bounded min-heap, O(n log k), result sorted descending")
+}
@@ -0,0 +1,417 @@
+// FILE: 09_generics/11_numeric_stats/11_numeric_stats.go
+// TOPIC: Numeric Stats — moving averages (simple + exponential), quantiles, histograms, weighted sampling
+//
+// Run: go run 09_generics/11_numeric_stats/11_numeric_stats.go
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+)
+
+// ── CONSTRAINTS (same as 05_generic_functions) ────────────────────────────────
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+var ErrInvalidWindow = errors.New("window size must be positive")
+
+// ── WINDOW FUNC — apply f to every full sliding window ───────────────────────
+// For s = [a b c d] and size 3 the windows are [a b c] and [b c d]:
+// len(s)-size+1 of them. Each window is a sub-slice of s (no copy), so f
+// must not hold on to it or modify it.
+
+// WindowFunc calls f on each consecutive window of the given size and
+// collects the results. A window larger than s yields an empty result.
+func WindowFunc[T, R any](s []T, size int, f func(window []T) R) ([]R, error) {
+	if size <= 0 {
+		return nil, ErrInvalidWindow
+	}
+	if size > len(s) {
+		return []R{}, nil
+	}
+	result := make([]R, 0, len(s)-size+1)
+	for i := 0; i+size <= len(s); i++ {
+		result = append(result, f(s[i:i+size]))
+	}
+	return result, nil
+}
+
+// ── MOVING AVERAGE — the classic smoothing filter ────────────────────────────
+// Built on WindowFunc for clarity; a running sum (add the new element,
+// subtract the one leaving) would make it O(n) instead of O(n·window).
+// Summing in float64 avoids integer overflow for small int types.
+
+// MovingAverage returns the mean of every window of the given size.
+func MovingAverage[T Number](s []T, window int) ([]float64, error) {
+	return WindowFunc(s, window, func(w []T) float64 {
+		var sum float64
+		for _, v := range w {
+			sum += float64(v)
+		}
+		return sum / float64(len(w))
+	})
+}
+
+// ── MOVING REDUCE — any aggregate over a sliding window ──────────────────────
+// MovingAverage is one reducer; max, sum and median are others. MovingReduce
+// is WindowFunc under the name the moving-* family uses, with the same
+// contract: window <= 0 is ErrInvalidWindow, a window longer than s gives an
+// empty result, and each window is a sub-slice the reducer must not keep or
+// modify (clone it first if, e.g., you need to sort it).
+
+// MovingReduce applies reduce to every sliding window of the given size.
+func MovingReduce[T, R any](s []T, window int, reduce func([]T) R) ([]R, error) {
+	return WindowFunc(s, window, reduce)
+}
+
+// ── EMA — exponential moving average in O(1) memory ──────────────────────────
+// A simple moving average must remember the whole window. An EMA remembers a
+// single number and blends each new sample in:
+//
+//   ema₁ = x₁                         (first sample initializes)
+//   emaₙ = α·xₙ + (1-α)·emaₙ₋₁
+//
+// α in (0, 1] controls responsiveness: α=1 just tracks the last sample,
+// small α smooths heavily. A rough equivalent to an N-sample SMA is
+// α = 2/(N+1). Ideal for latency smoothing where you only need "recent".
+
+type EMA struct {
+	alpha   float64
+	value   float64
+	started bool
+}
+
+func NewEMA(alpha float64) (*EMA, error) {
+	if !(alpha > 0 && alpha <= 1) { // also rejects NaN
+		return nil, fmt.Errorf("ema: alpha %v outside (0, 1]", alpha)
+	}
+	return &EMA{alpha: alpha}, nil
+}
+
+// Add folds x into the average and returns the updated value.
+func (e *EMA) Add(x float64) float64 {
+	if !e.started {
+		e.value, e.started = x, true
+		return e.value
+	}
+	e.value = e.alpha*x + (1-e.alpha)*e.value
+	return e.value
+}
+
+// Value returns the current average (0 before the first Add).
+func (e *EMA) Value() float64 { return e.value }
+
+// ── EXACT QUANTILE — sort and interpolate ─────────────────────────────────────
+// The q-quantile of sorted data x[0..n-1] sits at fractional index h=(n-1)q.
+// Between the two neighbouring samples we interpolate linearly — the default
+// in NumPy, R (type 7) and most spreadsheets. So for 1..100:
+//   median (q=0.5)  → h=49.5  → halfway between 50 and 51 = 50.5
+//   p95    (q=0.95) → h=94.05 → 95 + 0.05·(96-95)          = 95.05
+// O(n log n) and needs all the data — fine for a batch, not for a stream.
+
+var ErrNoData = errors.New("no data")
+
+// ExactQuantile returns the q-quantile (0 <= q <= 1) of values.
+// values is not modified.
+func ExactQuantile(values []float64, q float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrNoData
+	}
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, fmt.Errorf("quantile %v outside [0, 1]", q)
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	h := float64(len(sorted)-1) * q
+	lo := int(math.Floor(h))
+	hi := int(math.Ceil(h))
+	return sorted[lo] + (h-float64(lo))*(sorted[hi]-sorted[lo]), nil
+}
+
+// ── QUANTILE ESTIMATOR — bounded memory over a stream ────────────────────────
+// Latency monitoring sees millions of samples; keeping them all to compute a
+// p99 is not an option. Reservoir sampling (Algorithm R) keeps a uniform
+// random sample of fixed size k: the i-th value replaces a random slot with
+// probability k/i. Quantiles of the sample estimate quantiles of the stream.
+//
+// Accuracy depends on k — ±1–2 percentile points at k=1000 is typical. Tail
+// quantiles (p99.9) need a much bigger reservoir, or a sketch such as P²,
+// t-digest or HDR histograms. Not safe for concurrent use; wrap in a mutex.
+
+type QuantileEstimator struct {
+	reservoir []float64
+	size      int
+	seen      int
+	rng       *rand.Rand
+}
+
+// NewQuantileEstimator keeps at most size samples; seed makes runs repeatable.
+func NewQuantileEstimator(size int, seed int64) *QuantileEstimator {
+	if size < 1 {
+		size = 1
+	}
+	return &QuantileEstimator{
+		reservoir: make([]float64, 0, size),
+		size:      size,
+		rng:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (e *QuantileEstimator) Add(x float64) {
+	e.seen++
+	if len(e.reservoir) < e.size {
+		e.reservoir = append(e.reservoir, x)
+		return
+	}
+	if j := e.rng.Intn(e.seen); j < e.size {
+		e.reservoir[j] = x
+	}
+}
+
+// Count returns how many values have been added (not how many are kept).
+func (e *QuantileEstimator) Count() int { return e.seen }
+
+// Quantile estimates the q-quantile; NaN if nothing was added or q is invalid.
+func (e *QuantileEstimator) Quantile(q float64) float64 {
+	v, err := ExactQuantile(e.reservoir, q)
+	if err != nil {
+		return math.NaN()
+	}
+	return v
+}
+
+// ── HISTOGRAM — counts per bucket, Prometheus-style boundaries ───────────────
+// bounds are the UPPER edges of the buckets, ascending. For bounds [10 50 100]
+// the result has len(bounds)+1 counts:
+//
+//   counts[0]  v ≤ 10          counts[2]  50 < v ≤ 100
+//   counts[1]  10 < v ≤ 50     counts[3]  v > 100      (overflow)
+//
+// Upper edges are INCLUSIVE, as with Prometheus `le` buckets: a 50ms request
+// lands in the "≤ 50" bucket. Everything below the first bound goes in
+// counts[0] and everything above the last in the overflow bucket, so the
+// counts always sum to the number of non-NaN values; NaN is skipped.
+// One binary search per value: O(n log b).
+
+// Histogram counts values into the buckets described by bounds. Panics if
+// bounds is not sorted ascending.
+func Histogram(values []float64, bounds []float64) []int {
+	if !slices.IsSorted(bounds) {
+		panic("Histogram: bounds must be sorted ascending")
+	}
+	counts := make([]int, len(bounds)+1)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		counts[sort.SearchFloat64s(bounds, v)]++ // first bound >= v
+	}
+	return counts
+}
+
+// ── WEIGHTED CHOOSER — pick items proportionally to weight ───────────────────
+// Lay the weights end to end on a number line and throw a dart:
+//
+//   weights  [5,        3,      2]
+//   cumul.   [5,        8,      10]
+//            |---a-----|--b---|-c-|      r = rng.Float64()*10
+//
+// The item whose segment contains r wins — the first cumulative value > r,
+// found by binary search: O(log n) per Pick after O(n) setup.
+// A zero weight gives an empty segment, so that item is never picked.
+//
+// Pick takes the *rand.Rand explicitly: pass a seeded one for reproducible
+// tests/simulations. A *rand.Rand is NOT safe for concurrent use.
+
+type WeightedChooser[T any] struct {
+	items []T
+	cumul []float64
+	total float64
+}
+
+func NewWeightedChooser[T any](items []T, weights []float64) (*WeightedChooser[T], error) {
+	if len(items) != len(weights) {
+		return nil, fmt.Errorf("weighted chooser: %d items but %d weights", len(items), len(weights))
+	}
+	cumul := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("weighted chooser: invalid weight %v for item %d", w, i)
+		}
+		total += w
+		cumul[i] = total
+	}
+	if total == 0 {
+		return nil, errors.New("weighted chooser: weights sum to zero")
+	}
+	return &WeightedChooser[T]{items: slices.Clone(items), cumul: cumul, total: total}, nil
+}
+
+// Pick returns a random item with probability weight/total.
+func (c *WeightedChooser[T]) Pick(rng *rand.Rand) T {
+	r := rng.Float64() * c.total
+	i := sort.Search(len(c.cumul), func(i int) bool { return c.cumul[i] > r })
+	if i == len(c.cumul) { // r rounded up to total: take the last item with weight > 0
+		i = sort.SearchFloat64s(c.cumul, c.total)
+	}
+	return c.items[i]
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Numeric Stats")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── WindowFunc ────────────────────────────────────────────────────────
+	fmt.Println("\n── WindowFunc ──")
+	spans, _ := WindowFunc([]string{"a", "b", "c", "d"}, 2, func(w []string) string {
+		return w[0] + w[1]
+	})
+	fmt.Printf("  pairs of [a b c d]: %v\n", spans)
+
+	// ── MovingAverage ─────────────────────────────────────────────────────
+	fmt.Println("\n── MovingAverage ──")
+	avg, err := MovingAverage([]int{1, 2, 3, 4, 5}, 3)
+	fmt.Printf("  [1 2 3 4 5], window 3: %v err=%v\n", avg, err)
+
+	prices := []float64{10.0, 10.5, 11.0, 10.0, 9.5, 12.0}
+	avg, _ = MovingAverage(prices, 2)
+	fmt.Printf("  prices %v, window 2: %v\n", prices, avg)
+
+	avg, err = MovingAverage([]uint8{200, 250, 255}, 3)
+	fmt.Printf("  uint8 [200 250 255], window 3: %v (no overflow) err=%v\n", avg, err)
+
+	avg, err = MovingAverage([]int{1, 2}, 5)
+	fmt.Printf("  window > len: %v (len %d) err=%v\n", avg, len(avg), err)
+
+	_, err = MovingAverage([]int{1, 2, 3}, 0)
+	fmt.Printf("  window 0: err=%v\n", err)
+
+	// ── MovingReduce ──────────────────────────────────────────────────────
+	fmt.Println("\n── MovingReduce ──")
+	series := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	movingMax, _ := MovingReduce(series, 3, slices.Max[[]int])
+	manual := make([]int, 0, len(series)-2)
+	for i := 0; i+3 <= len(series); i++ {
+		manual = append(manual, max(series[i], series[i+1], series[i+2]))
+	}
+	fmt.Printf("  %v, window 3\n", series)
+	fmt.Printf("  moving max: %v (matches manual loop: %v)\n", movingMax, slices.Equal(movingMax, manual))
+	movingSum, _ := MovingReduce(series, 3, func(w []int) int {
+		total := 0
+		for _, v := range w {
+			total += v
+		}
+		return total
+	})
+	fmt.Printf("  moving sum: %v\n", movingSum)
+	movingMedian, _ := MovingReduce([]float64{1, 9, 2, 8, 3}, 3, func(w []float64) float64 {
+		m, _ := ExactQuantile(w, 0.5) // ExactQuantile sorts a copy, so w is untouched
+		return m
+	})
+	fmt.Printf("  moving median of [1 9 2 8 3]: %v\n", movingMedian)
+	_, err = MovingReduce(series, -1, slices.Max[[]int])
+	fmt.Printf("  window -1: err=%v\n", err)
+
+	// ── EMA ───────────────────────────────────────────────────────────────
+	fmt.Println("\n── EMA (alpha 0.5) ──")
+	ema, _ := NewEMA(0.5)
+	// By hand: 10 → 10; 20 → .5·20+.5·10=15; 30 → .5·30+.5·15=22.5; 10 → .5·10+.5·22.5=16.25
+	for _, x := range []float64{10, 20, 30, 10} {
+		fmt.Printf("  Add(%2.0f) → %.2f\n", x, ema.Add(x))
+	}
+	spiky, _ := NewEMA(0.1)
+	for _, ms := range []float64{100, 100, 100, 900, 100} {
+		spiky.Add(ms)
+	}
+	fmt.Printf("  alpha 0.1 over latencies [100 100 100 900 100]: %.1f (spike damped)\n", spiky.Value())
+	for _, a := range []float64{0, 1.5, math.NaN()} {
+		_, err := NewEMA(a)
+		fmt.Printf("  NewEMA(%v): %v\n", a, err)
+	}
+
+	// ── ExactQuantile ─────────────────────────────────────────────────────
+	fmt.Println("\n── ExactQuantile ──")
+	data := make([]float64, 100)
+	for i := range data {
+		data[i] = float64(100 - i) // 100..1, deliberately unsorted
+	}
+	med, _ := ExactQuantile(data, 0.5)
+	p95, _ := ExactQuantile(data, 0.95)
+	lo, _ := ExactQuantile(data, 0)
+	hi, _ := ExactQuantile(data, 1)
+	fmt.Printf("  1..100: median=%.2f p95=%.2f min=%.0f max=%.0f (input untouched: data[0]=%.0f)\n",
+		med, p95, lo, hi, data[0])
+	med, _ = ExactQuantile([]float64{7, 1, 3}, 0.5)
+	fmt.Printf("  [7 1 3] median=%.0f\n", med)
+	_, err = ExactQuantile(nil, 0.5)
+	fmt.Printf("  empty: err=%v\n", err)
+	_, err = ExactQuantile(data, 1.5)
+	fmt.Printf("  q=1.5: err=%v\n", err)
+
+	// ── QuantileEstimator ─────────────────────────────────────────────────
+	fmt.Println("\n── QuantileEstimator (reservoir of 1000) ──")
+	est := NewQuantileEstimator(1000, 42)
+	gen := rand.New(rand.NewSource(7))
+	for i := 0; i < 100_000; i++ {
+		est.Add(gen.Float64() * 200) // latencies uniform in [0, 200) ms → p50≈100, p95≈190
+	}
+	fmt.Printf("  %d samples, memory for 1000: p50≈%.1f p95≈%.1f p99≈%.1f\n",
+		est.Count(), est.Quantile(0.5), est.Quantile(0.95), est.Quantile(0.99))
+	fmt.Printf("  empty estimator: %v\n", NewQuantileEstimator(10, 1).Quantile(0.5))
+
+	// ── Histogram ─────────────────────────────────────────────────────────
+	fmt.Println("\n── Histogram ──")
+	bounds := []float64{10, 50, 100}
+	latencies := []float64{3, 10, 10.5, 42, 50, 51, 99, 100, 250, math.NaN()}
+	counts := Histogram(latencies, bounds)
+	fmt.Printf("  bounds %v, values %v\n", bounds, latencies)
+	labels := []string{"≤ 10", "10 < v ≤ 50", "50 < v ≤ 100", "> 100"}
+	for i, c := range counts {
+		fmt.Printf("    %-13s %d\n", labels[i], c)
+	}
+	fmt.Println("  (10, 50 and 100 sit exactly on a bound → counted in the bucket they close; NaN skipped)")
+	fmt.Printf("  no bounds: %v (one overflow bucket)\n", Histogram([]float64{1, 2}, nil))
+
+	// ── WeightedChooser ───────────────────────────────────────────────────
+	fmt.Println("\n── WeightedChooser (seeded, 100k picks) ──")
+	backendsW := []string{"big", "medium", "small", "drained"}
+	weightsW := []float64{5, 3, 2, 0}
+	chooser, err := NewWeightedChooser(backendsW, weightsW)
+	fmt.Printf("  construct: err=%v\n", err)
+	pickRng := rand.New(rand.NewSource(99))
+	picks := map[string]int{}
+	const trials = 100_000
+	for i := 0; i < trials; i++ {
+		picks[chooser.Pick(pickRng)]++
+	}
+	for i, b := range backendsW {
+		fmt.Printf("  %-8s weight %.0f → expected %4.1f%%, got %4.1f%%\n",
+			b, weightsW[i], weightsW[i]/10*100, float64(picks[b])/trials*100)
+	}
+	_, err = NewWeightedChooser([]string{"a", "b"}, []float64{1})
+	fmt.Printf("  length mismatch: %v\n", err)
+	_, err = NewWeightedChooser([]string{"a", "b"}, []float64{1, -2})
+	fmt.Printf("  negative weight: %v\n", err)
+	_, err = NewWeightedChooser([]string{"a"}, []float64{0})
+	fmt.Printf("  all zero:        %v\n", err)
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  WindowFunc: f over each of len(s)-size+1 sliding sub-slices")
+	fmt.Println("  MovingAverage: window mean in float64; size <= 0 is an error")
+	fmt.Println("  MovingReduce: any reducer (max, sum, median) over sliding windows")
+	fmt.Println("  EMA: α·x + (1-α)·prev, first sample seeds it, α in (0, 1]")
+	fmt.Println("  ExactQuantile: sort + linear interpolation at (n-1)·q")
+	fmt.Println("  QuantileEstimator: reservoir sample, bounded memory, approximate")
+	fmt.Println("  Histogram: inclusive upper bounds, edge + overflow buckets, binary search")
+	fmt.Println("  WeightedChooser: cumulative weights + binary search per Pick")
+}
@@ -10,7 +10,8 @@
 //   - Goroutines (async observer notifications)
 //
 // Patterns covered:
-//   1. Observer    — event system with callbacks / fan-out
+//   1. Observer    — event system with callbacks / fan-out, event-sourced replay,
+//                    typed Observable[T]
 //   2. Strategy    — inject algorithm via interface OR function
 //   3. Command     — encapsulate operations as values
 //   4. Iterator    — channel-based and interface-based iteration
@@ -137,6 +138,150 @@ func (b *EventBus) PublishAsync(eventType EventType, payload interface{}) {
 	}
 }
 
+// --- Event sourcing: rebuilding state from the event log ---
+//
+// The bus above delivers events and forgets them. Event sourcing keeps them:
+// the event log is the source of truth, and the current state is just a fold
+// over it — Reduce with events as the input. Replaying the same events always
+// produces the same state, which gives you audit history and rebuildable
+// read models for free.
+
+// Aggregate folds events into a state S using a reducer fixed at construction.
+type Aggregate[S any] struct {
+	initial S
+	state   S
+	apply   func(S, Event) S
+}
+
+func NewAggregate[S any](initial S, apply func(S, Event) S) *Aggregate[S] {
+	return &Aggregate[S]{initial: initial, state: initial, apply: apply}
+}
+
+// Apply folds one new event into the current state.
+func (a *Aggregate[S]) Apply(e Event) {
+	a.state = a.apply(a.state, e)
+}
+
+// Replay rebuilds the state from the initial value and the given events.
+func (a *Aggregate[S]) Replay(events []Event) S {
+	a.state = a.initial
+	for _, e := range events {
+		a.Apply(e)
+	}
+	return a.state
+}
+
+func (a *Aggregate[S]) State() S { return a.state }
+
+// --- Observable[T]: typed single-value state ---
+//
+// The EventBus is stringly-typed: handlers get an interface{} payload and must
+// type-assert it. For ONE piece of state (current config, connection status,
+// selected tab) an Observable is simpler: subscribers receive old and new
+// values with their real type.
+//
+// Subscriptions get a unique ID, which is what makes unsubscribe work —
+// exactly the fix the EventBus.Subscribe comment above points at, since
+// funcs can't be compared.
+//
+// Notification happens OUTSIDE the lock, so a subscriber may call Get (or
+// even Set) without deadlocking. Delivery is still ORDERED: each change goes
+// on a per-Observable queue, and whichever Set finds nobody delivering drains
+// it. Every subscriber therefore sees changes in the order they were stored,
+// and each old value is the previous notification's new value. The price: a
+// Set made from a subscriber, or while another goroutine is delivering,
+// returns before its own notification runs — it is queued, not dropped.
+// NewDistinctObservable skips notification when the new value == the old one;
+// it needs comparable T, which is why it's a separate constructor rather than
+// a flag (methods can't add constraints).
+
+type subscription[T any] struct {
+	id int
+	fn func(old, new T)
+}
+
+// change is one queued notification, with the subscribers as of its Set.
+type change[T any] struct {
+	old, new T
+	subs     []subscription[T]
+}
+
+type Observable[T any] struct {
+	mu         sync.Mutex
+	value      T
+	subs       []subscription[T]
+	nextID     int
+	equal      func(a, b T) bool // nil → always notify
+	pending    []change[T]
+	delivering bool // some Set is draining pending
+}
+
+func NewObservable[T any](initial T) *Observable[T] {
+	return &Observable[T]{value: initial}
+}
+
+func NewDistinctObservable[T comparable](initial T) *Observable[T] {
+	return &Observable[T]{value: initial, equal: func(a, b T) bool { return a == b }}
+}
+
+func (o *Observable[T]) Get() T {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.value
+}
+
+// Set stores v and notifies every subscriber with (old, v), in order with
+// every other Set (see the queue note above).
+func (o *Observable[T]) Set(v T) {
+	o.mu.Lock()
+	old := o.value
+	o.value = v
+	if o.equal != nil && o.equal(old, v) {
+		o.mu.Unlock()
+		return
+	}
+	subs := make([]subscription[T], len(o.subs))
+	copy(subs, o.subs)
+	o.pending = append(o.pending, change[T]{old: old, new: v, subs: subs})
+	if o.delivering {
+		o.mu.Unlock() // the active deliverer will get to it
+		return
+	}
+	o.delivering = true
+	for len(o.pending) > 0 {
+		c := o.pending[0]
+		o.pending = o.pending[1:]
+		o.mu.Unlock()
+		for _, s := range c.subs {
+			s.fn(c.old, c.new)
+		}
+		o.mu.Lock()
+	}
+	o.delivering = false
+	o.mu.Unlock()
+}
+
+// Subscribe registers fn and returns a func that removes it. Calling the
+// returned func more than once is harmless.
+func (o *Observable[T]) Subscribe(fn func(old, new T)) (unsubscribe func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	id := o.nextID
+	o.nextID++
+	o.subs = append(o.subs, subscription[T]{id: id, fn: fn})
+
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		for i, s := range o.subs {
+			if s.id == id {
+				o.subs = append(o.subs[:i:i], o.subs[i+1:]...) // fresh array: in-flight copies unaffected
+				return
+			}
+		}
+	}
+}
+
 // =============================================================================
 // PATTERN 2: STRATEGY
 // =============================================================================
@@ -647,6 +792,104 @@ func main() {
 	bus.Publish(EventOrderPlaced, map[string]interface{}{"orderId": "o456", "total": 99.99})
 	fmt.Println()
 
+	// Event sourcing: the state of a user-directory is a fold over its events.
+	fmt.Println("  Replaying user events into an Aggregate:")
+	const (
+		userCreated EventType = "user.created"
+		userUpdated EventType = "user.updated"
+		userDeleted EventType = "user.deleted"
+	)
+	type userChange struct{ ID, Email string }
+	users := NewAggregate(map[string]string{}, func(state map[string]string, e Event) map[string]string {
+		next := make(map[string]string, len(state)) // copy: each state is an immutable snapshot
+		for k, v := range state {
+			next[k] = v
+		}
+		c := e.Payload.(userChange)
+		switch e.Type {
+		case userCreated, userUpdated:
+			next[c.ID] = c.Email
+		case userDeleted:
+			delete(next, c.ID)
+		}
+		return next
+	})
+	log := []Event{
+		{Type: userCreated, Payload: userChange{"u1", "alice@old.com"}},
+		{Type: userCreated, Payload: userChange{"u2", "bob@example.com"}},
+		{Type: userUpdated, Payload: userChange{"u1", "alice@new.com"}},
+		{Type: userDeleted, Payload: userChange{ID: "u2"}},
+	}
+	fmt.Printf("  after replaying %d events: %v\n", len(log), users.Replay(log))
+	users.Apply(Event{Type: userCreated, Payload: userChange{"u3", "carol@example.com"}})
+	fmt.Printf("  after one more Apply:      %v\n", users.State())
+	fmt.Printf("  replay again from scratch: %v\n", users.Replay(log))
+	fmt.Println()
+
+	fmt.Println("  Observable[T] — typed old/new notifications:")
+	type ConnState string
+	conn := NewObservable[ConnState]("disconnected")
+	unsubLog := conn.Subscribe(func(old, new ConnState) {
+		fmt.Printf("  [log]     %s → %s\n", old, new)
+	})
+	conn.Subscribe(func(old, new ConnState) {
+		if new == "connected" {
+			fmt.Printf("  [metrics] reconnect counted (was %s)\n", old)
+		}
+	})
+	conn.Set("connecting")
+	conn.Set("connected")
+	unsubLog()
+	unsubLog() // second call is a no-op
+	conn.Set("disconnected")
+	fmt.Printf("  Get() after unsubscribing [log]: %s\n", conn.Get())
+
+	temp := NewDistinctObservable(20)
+	changes := 0
+	temp.Subscribe(func(old, new int) { changes++ })
+	for _, t := range []int{20, 21, 21, 21, 22} {
+		temp.Set(t)
+	}
+	fmt.Printf("  NewDistinctObservable: 5 Sets, %d notifications (repeats skipped)\n", changes)
+
+	// A Set from inside a subscriber is queued, so [log] still sees
+	// connecting→connected BEFORE connected→ready.
+	link := NewObservable[ConnState]("disconnected")
+	link.Subscribe(func(old, new ConnState) {
+		if new == "connected" {
+			link.Set("ready")
+		}
+	})
+	link.Subscribe(func(old, new ConnState) {
+		fmt.Printf("  [log]     %s → %s\n", old, new)
+	})
+	link.Set("connecting")
+	link.Set("connected")
+
+	// Concurrent Sets: every notification's old must equal the previous new.
+	counter := NewObservable(0)
+	last, broken := 0, 0
+	counter.Subscribe(func(old, new int) {
+		if old != last {
+			broken++
+		}
+		last = new
+	})
+	var setWG sync.WaitGroup
+	for g := 1; g <= 8; g++ {
+		setWG.Add(1)
+		go func(g int) {
+			defer setWG.Done()
+			for i := 0; i < 100; i++ {
+				counter.Set(g*1000 + i)
+			}
+		}(g)
+	}
+	setWG.Wait()
+	fmt.Printf("  8 goroutines × 100 Sets: %d out-of-order notifications, last=%d matches Get()=%v\n",
+		broken, last, last == counter.Get())
+	fmt.Println()
+
 	// ------------------------------------------------------------------
 	// 2. STRATEGY
 	// ------------------------------------------------------------------
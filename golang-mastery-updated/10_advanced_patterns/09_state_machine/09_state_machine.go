@@ -0,0 +1,317 @@
+// FILE: 10_advanced_patterns/09_state_machine/09_state_machine.go
+// TOPIC: Generic State Machine — declarative transitions, a circuit breaker built on it
+//
+// Run: go run 10_advanced_patterns/09_state_machine/09_state_machine.go
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ── STATE MACHINE ─────────────────────────────────────────────────────────────
+// Many "smart" types are state machines in disguise: a connection, an order,
+// a circuit breaker. Written by hand, the legal transitions hide inside
+// if/else chains. Declaring them as a table makes them explicit and lets the
+// machine reject anything that isn't listed.
+//
+//   sm.AddTransition(Closed, Trip, Open)   // from + event → to
+//   sm.Fire(Trip)                          // error if (current, Trip) undefined
+//
+// S and E only need to be comparable — they're used as map keys.
+
+var ErrInvalidTransition = errors.New("invalid transition")
+
+type transitionKey[S, E comparable] struct {
+	from S
+	on   E
+}
+
+type StateMachine[S, E comparable] struct {
+	mu          sync.Mutex
+	current     S
+	transitions map[transitionKey[S, E]]S
+	hooks       []func(from, to S, on E)
+}
+
+func NewStateMachine[S, E comparable](initial S) *StateMachine[S, E] {
+	return &StateMachine[S, E]{
+		current:     initial,
+		transitions: make(map[transitionKey[S, E]]S),
+	}
+}
+
+// AddTransition declares that event `on` moves the machine from `from` to `to`.
+func (m *StateMachine[S, E]) AddTransition(from S, on E, to S) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transitions[transitionKey[S, E]{from, on}] = to
+}
+
+// OnTransition registers a hook called after every successful transition.
+func (m *StateMachine[S, E]) OnTransition(fn func(from, to S, on E)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, fn)
+}
+
+// Fire applies event to the current state.
+// Hooks run after the lock is released, so they may call Current or Fire.
+func (m *StateMachine[S, E]) Fire(event E) error {
+	m.mu.Lock()
+	from := m.current
+	to, ok := m.transitions[transitionKey[S, E]{from, event}]
+	if !ok {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: no transition from %v on %v", ErrInvalidTransition, from, event)
+	}
+	m.current = to
+	hooks := make([]func(from, to S, on E), len(m.hooks))
+	copy(hooks, m.hooks)
+	m.mu.Unlock()
+
+	for _, h := range hooks {
+		h(from, to, event)
+	}
+	return nil
+}
+
+func (m *StateMachine[S, E]) Current() S {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current
+}
+
+// ── CIRCUIT BREAKER — expressed as a state machine ────────────────────────────
+// Closed   : calls pass through; consecutive failures are counted
+// Open     : calls fail fast with ErrBreakerOpen until the cooldown elapses
+// HalfOpen : one trial call — success closes the breaker, failure re-opens it;
+//            other callers get ErrBreakerOpen while the probe is in flight
+//
+//   Closed ──trip──▶ Open ──probe──▶ HalfOpen ──reset──▶ Closed
+//                     ▲                  │
+//                     └──────trip────────┘
+
+type BreakerState int
+
+const (
+	Closed BreakerState = iota
+	Open
+	HalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case Closed:
+		return "Closed"
+	case Open:
+		return "Open"
+	case HalfOpen:
+		return "HalfOpen"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+type BreakerEvent string
+
+const (
+	EventTrip  BreakerEvent = "trip"
+	EventProbe BreakerEvent = "probe"
+	EventReset BreakerEvent = "reset"
+)
+
+var ErrBreakerOpen = errors.New("circuit breaker is open")
+
+type CircuitBreaker struct {
+	sm        *StateMachine[BreakerState, BreakerEvent]
+	mu        sync.Mutex
+	failures  int
+	threshold int
+	cooldown  time.Duration
+	openedAt  time.Time
+	probing   bool // a HalfOpen trial call is running
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	sm := NewStateMachine[BreakerState, BreakerEvent](Closed)
+	sm.AddTransition(Closed, EventTrip, Open)
+	sm.AddTransition(Open, EventProbe, HalfOpen)
+	sm.AddTransition(HalfOpen, EventReset, Closed)
+	sm.AddTransition(HalfOpen, EventTrip, Open)
+	return &CircuitBreaker{sm: sm, threshold: threshold, cooldown: cooldown}
+}
+
+func (cb *CircuitBreaker) State() BreakerState { return cb.sm.Current() }
+
+// Call runs fn through the breaker.
+//
+// Only the caller that moves the breaker to HalfOpen runs fn as the probe;
+// the probe alone decides between reset and trip. Calls that started while
+// Closed and finish after the breaker tripped don't change its state.
+func (cb *CircuitBreaker) Call(fn func() error) error {
+	cb.mu.Lock()
+	probe := false
+	switch cb.sm.Current() {
+	case Open:
+		if time.Since(cb.openedAt) < cb.cooldown {
+			cb.mu.Unlock()
+			return ErrBreakerOpen
+		}
+		if err := cb.sm.Fire(EventProbe); err != nil { // cooldown over — allow a trial call
+			cb.mu.Unlock()
+			return err
+		}
+		cb.probing, probe = true, true
+	case HalfOpen:
+		if cb.probing {
+			cb.mu.Unlock()
+			return ErrBreakerOpen // don't let everyone hit a recovering dependency
+		}
+		cb.probing, probe = true, true
+	}
+	cb.mu.Unlock()
+
+	err := fn()
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if probe {
+		cb.probing = false
+	}
+	state := cb.sm.Current()
+	if err != nil {
+		cb.failures++
+		if (probe && state == HalfOpen) || (state == Closed && cb.failures >= cb.threshold) {
+			if ferr := cb.sm.Fire(EventTrip); ferr != nil {
+				return errors.Join(err, ferr)
+			}
+			cb.openedAt = time.Now()
+		}
+		return err
+	}
+	cb.failures = 0
+	if probe && state == HalfOpen {
+		if ferr := cb.sm.Fire(EventReset); ferr != nil {
+			return ferr
+		}
+	}
+	return nil
+}
+
+// CallBreaker is Call for functions that return a value. Go methods can't
+// have their own type parameters, so this is a free function wrapping Call:
+// the breaker's state handling is unchanged. When the breaker is open, fn
+// is not run and the result is T's zero value with ErrBreakerOpen.
+// Otherwise fn's value and error are returned as-is.
+func CallBreaker[T any](cb *CircuitBreaker, fn func() (T, error)) (T, error) {
+	var result T
+	err := cb.Call(func() error {
+		var err error
+		result, err = fn()
+		return err
+	})
+	return result, err
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Generic State Machine")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── StateMachine basics ───────────────────────────────────────────────
+	fmt.Println("\n── StateMachine[S, E] ──")
+	door := NewStateMachine[string, string]("closed")
+	door.AddTransition("closed", "open", "opened")
+	door.AddTransition("opened", "close", "closed")
+	door.AddTransition("closed", "lock", "locked")
+	door.AddTransition("locked", "unlock", "closed")
+
+	door.OnTransition(func(from, to, on string) {
+		fmt.Printf("  hook: %s --%s--> %s\n", from, on, to)
+	})
+
+	fmt.Printf("  Fire(open):  err=%v, current=%s\n", door.Fire("open"), door.Current())
+	err := door.Fire("lock") // can't lock an open door
+	fmt.Printf("  Fire(lock):  err=%v\n", err)
+	fmt.Printf("  errors.Is(err, ErrInvalidTransition): %v, current still %s\n",
+		errors.Is(err, ErrInvalidTransition), door.Current())
+
+	// ── CircuitBreaker on top of it ───────────────────────────────────────
+	fmt.Println("\n── CircuitBreaker (declarative transitions) ──")
+	cb := NewCircuitBreaker(2, 50*time.Millisecond)
+	cb.sm.OnTransition(func(from, to BreakerState, on BreakerEvent) {
+		fmt.Printf("  breaker: %v --%s--> %v\n", from, on, to)
+	})
+
+	failing := func() error { return errors.New("upstream timeout") }
+	healthy := func() error { return nil }
+
+	for i := 1; i <= 3; i++ {
+		fmt.Printf("  call %d: %v (state=%v)\n", i, cb.Call(failing), cb.State())
+	}
+	time.Sleep(60 * time.Millisecond)
+	fmt.Printf("  after cooldown: %v (state=%v)\n", cb.Call(healthy), cb.State())
+
+	// HalfOpen admits ONE probe; concurrent callers are turned away meanwhile.
+	// ── HalfOpen probe ────────────────────────────────────────────────────
+	fmt.Println("\n── CircuitBreaker (single HalfOpen probe) ──")
+	recovering := NewCircuitBreaker(1, 10*time.Millisecond)
+	recovering.Call(failing)
+	time.Sleep(20 * time.Millisecond)
+	var (
+		wg              sync.WaitGroup
+		countMu         sync.Mutex
+		ran, turnedAway int
+	)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := recovering.Call(func() error {
+				countMu.Lock()
+				ran++
+				countMu.Unlock()
+				time.Sleep(20 * time.Millisecond) // slow probe: the others arrive meanwhile
+				return nil
+			})
+			if errors.Is(err, ErrBreakerOpen) {
+				countMu.Lock()
+				turnedAway++
+				countMu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("  5 concurrent calls after cooldown: %d probe ran, %d got ErrBreakerOpen, state=%v\n",
+		ran, turnedAway, recovering.State())
+	fmt.Printf("  unknown state prints safely: %v\n", BreakerState(7))
+
+	// ── CallBreaker[T] ────────────────────────────────────────────────────
+	fmt.Println("\n── CallBreaker[T] (typed result) ──")
+	users := NewCircuitBreaker(1, time.Hour)
+	fetchUser := func(id int) func() (string, error) {
+		return func() (string, error) {
+			if id < 0 {
+				return "", errors.New("upstream 500")
+			}
+			return fmt.Sprintf("user-%d", id), nil
+		}
+	}
+	name, err := CallBreaker(users, fetchUser(7))
+	fmt.Printf("  closed:  %q err=%v\n", name, err)
+	name, err = CallBreaker(users, fetchUser(-1))
+	fmt.Printf("  failure: %q err=%v (state=%v)\n", name, err, users.State())
+	name, err = CallBreaker(users, fetchUser(8))
+	fmt.Printf("  open:    %q err=%v, zero value=%v, is ErrBreakerOpen=%v\n",
+		name, err, name == "", errors.Is(err, ErrBreakerOpen))
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  StateMachine[S,E]: transition table + Fire + OnTransition hooks")
+	fmt.Println("  Undefined transitions return ErrInvalidTransition (state unchanged)")
+	fmt.Println("  CircuitBreaker: Closed/Open/HalfOpen declared as four transitions")
+	fmt.Println("  HalfOpen: one probe at a time; other callers fail fast until it ends")
+	fmt.Println("  CallBreaker[T]: typed result through Call; open → zero T + ErrBreakerOpen")
+}
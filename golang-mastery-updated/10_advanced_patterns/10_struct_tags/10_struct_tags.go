@@ -0,0 +1,500 @@
+// FILE: 10_advanced_patterns/10_struct_tags/10_struct_tags.go
+// TOPIC: Struct Tags in Practice — reflection-driven validation, diffing and env config
+//
+// Run: go run 10_advanced_patterns/10_struct_tags/10_struct_tags.go
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ── ERROR TYPES (same shapes as module 04) ────────────────────────────────────
+
+type ValidationError struct {
+	Field   string
+	Value   interface{}
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation failed on field %q (value=%v): %s",
+		e.Field, e.Value, e.Message)
+}
+
+type MultiError struct {
+	Errors []error
+}
+
+func (m *MultiError) Error() string {
+	msgs := make([]string, 0, len(m.Errors))
+	for _, e := range m.Errors {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (m *MultiError) Unwrap() []error { return m.Errors }
+
+// OrNil avoids the typed-nil trap: an empty *MultiError is still a non-nil error.
+func (m *MultiError) OrNil() error {
+	if len(m.Errors) == 0 {
+		return nil
+	}
+	return m
+}
+
+// ── VALIDATE — `validate:"..."` tags ──────────────────────────────────────────
+// Supported rules (comma-separated, e.g. `validate:"required,min=3,max=20"`):
+//
+//   required  value must not be the zero value
+//   min=N     numbers: value >= N; strings: rune count >= N; slices/maps: len >= N
+//   max=N     same as min, upper bound
+//   email     string must match the module 08 email pattern (empty is skipped —
+//             combine with `required` to forbid it)
+//
+// Nested structs (and non-nil struct pointers) are validated recursively, and
+// their errors carry dotted paths like "Address.Zip". Unexported fields are
+// skipped — reflection can read them but they aren't part of the input.
+
+var reEmail = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+
+// Validate checks v (a struct or pointer to struct) and returns a *MultiError
+// of *ValidationError for every failing rule, or nil.
+func Validate(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return errors.New("validate: nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: expected struct, got %s", rv.Kind())
+	}
+	me := &MultiError{}
+	validateStruct(rv, "", me)
+	return me.OrNil()
+}
+
+func validateStruct(rv reflect.Value, prefix string, me *MultiError) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		path := prefix + field.Name
+		value := rv.Field(i)
+
+		if tag := field.Tag.Get("validate"); tag != "" {
+			for _, rule := range strings.Split(tag, ",") {
+				rule = strings.TrimSpace(rule)
+				if msg := checkRule(value, rule); msg != "" {
+					me.Errors = append(me.Errors, &ValidationError{
+						Field: path, Value: value.Interface(), Message: msg,
+					})
+					if rule == "required" {
+						break // a missing value fails every other rule too — report once
+					}
+				}
+			}
+		}
+
+		// Recurse into nested structs
+		nested := value
+		if nested.Kind() == reflect.Ptr && !nested.IsNil() {
+			nested = nested.Elem()
+		}
+		if nested.Kind() == reflect.Struct {
+			validateStruct(nested, path+".", me)
+		}
+	}
+}
+
+// checkRule returns "" if value passes rule, otherwise a failure message.
+func checkRule(value reflect.Value, rule string) string {
+	name, arg, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if value.IsZero() {
+			return "is required"
+		}
+	case "min", "max":
+		limit, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Sprintf("bad rule %q", rule)
+		}
+		size, ok := measure(value)
+		if !ok {
+			return fmt.Sprintf("rule %q not supported for %s", rule, value.Kind())
+		}
+		if name == "min" && size < limit {
+			return fmt.Sprintf("must be at least %s", arg)
+		}
+		if name == "max" && size > limit {
+			return fmt.Sprintf("must be at most %s", arg)
+		}
+	case "email":
+		if value.Kind() != reflect.String {
+			return "email rule requires a string"
+		}
+		if s := value.String(); s != "" && !reEmail.MatchString(s) {
+			return "must be a valid email address"
+		}
+	default:
+		return fmt.Sprintf("unknown rule %q", rule)
+	}
+	return ""
+}
+
+// measure returns the number min/max compare against: the value itself for
+// numbers, the length for strings (in runes), slices, and maps.
+func measure(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(v.Len()), true
+	}
+	return 0, false
+}
+
+// ── STRUCT DIFF — "what changed?" for audit logs ──────────────────────────────
+// Walk two values of the SAME struct type field by field. Nested structs with
+// exported fields are descended into, so a change deep inside shows up as
+// "Address.City" rather than the whole Address. Any other field (slices,
+// maps, pointers) is a leaf compared with reflect.DeepEqual. Unexported
+// fields are skipped.
+//
+// Structs with NO exported fields, like time.Time, are leaves too —
+// descending would skip every field and never report a change. time.Time is
+// compared with Equal: the same instant in another time zone is no change.
+// The result maps dotted field path → the value from b (the new version).
+
+func StructDiff(a, b any) (map[string]any, error) {
+	va, err := derefStruct(a)
+	if err != nil {
+		return nil, err
+	}
+	vb, err := derefStruct(b)
+	if err != nil {
+		return nil, err
+	}
+	if va.Type() != vb.Type() {
+		return nil, fmt.Errorf("struct diff: type mismatch %s vs %s", va.Type(), vb.Type())
+	}
+	if va.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("struct diff: expected struct, got %s", va.Kind())
+	}
+	changes := make(map[string]any)
+	diffStruct(va, vb, "", changes)
+	return changes, nil
+}
+
+// derefStruct unwraps one level of pointer, rejecting nil interfaces and
+// nil pointers (reflect would panic on them further down).
+func derefStruct(x any) (reflect.Value, error) {
+	v := reflect.ValueOf(x)
+	if !v.IsValid() {
+		return v, errors.New("struct diff: nil value")
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, fmt.Errorf("struct diff: nil %s", v.Type())
+		}
+		v = v.Elem()
+	}
+	return v, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func diffStruct(va, vb reflect.Value, prefix string, changes map[string]any) {
+	rt := va.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		path := prefix + field.Name
+		fa, fb := va.Field(i), vb.Field(i)
+		switch {
+		case fa.Type() == timeType:
+			if !fa.Interface().(time.Time).Equal(fb.Interface().(time.Time)) {
+				changes[path] = fb.Interface()
+			}
+		case fa.Kind() == reflect.Struct && hasExportedFields(fa.Type()):
+			diffStruct(fa, fb, path+".", changes)
+		default:
+			if !reflect.DeepEqual(fa.Interface(), fb.Interface()) {
+				changes[path] = fb.Interface()
+			}
+		}
+	}
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// ── LOAD CONFIG — `env:"..."` and `default:"..."` tags ─────────────────────────
+// The twelve-factor way: configuration comes from the environment.
+//
+//   Port    int           `env:"APP_PORT" default:"8080"`
+//   Timeout time.Duration `env:"APP_TIMEOUT" default:"5s"`
+//
+// For each field with an env tag: a SET variable wins (even if empty), else
+// the default tag is used, else the field keeps whatever value it had — so
+// callers can pre-fill out with their own defaults. Supported field types:
+// string, bool, int*/uint*, float*, and time.Duration (checked before int64,
+// which is its underlying kind). Nested structs are walked like Validate.
+//
+// Every failing field is reported, not just the first: a *MultiError whose
+// entries wrap the strconv / time error, so one deploy shows all typos.
+
+// LoadConfig fills the struct pointed to by out from environment variables.
+func LoadConfig(out any) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("load config: expected non-nil pointer to struct, got %T", out)
+	}
+	me := &MultiError{}
+	loadStruct(rv.Elem(), "", me)
+	return me.OrNil()
+}
+
+func loadStruct(rv reflect.Value, prefix string, me *MultiError) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		path := prefix + field.Name
+		value := rv.Field(i)
+
+		name, ok := field.Tag.Lookup("env")
+		if !ok {
+			if value.Kind() == reflect.Struct {
+				loadStruct(value, path+".", me)
+			}
+			continue
+		}
+		raw, set := os.LookupEnv(name)
+		source := "env " + name
+		if !set {
+			if raw, set = field.Tag.Lookup("default"); !set {
+				continue // keep the existing value
+			}
+			source = "default"
+		}
+		if err := setFromString(value, raw); err != nil {
+			me.Errors = append(me.Errors, fmt.Errorf("%s: %s=%q: %w", path, source, raw, err))
+		}
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setFromString parses raw into v according to v's type.
+func setFromString(v reflect.Value, raw string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// ── Example types ─────────────────────────────────────────────────────────────
+
+type Address struct {
+	City string `validate:"required"`
+	Zip  string `validate:"required,min=5,max=5"`
+}
+
+type SignupForm struct {
+	Username string   `validate:"required,min=3,max=16"`
+	Email    string   `validate:"required,email"`
+	Age      int      `validate:"min=13,max=130"`
+	Tags     []string `validate:"max=3"`
+	Address  Address
+	Billing  *Address
+	internal string // unexported — never validated
+}
+
+type DBConfig struct {
+	URL      string `env:"DEMO_DB_URL" default:"postgres://localhost/app"`
+	MaxConns uint8  `env:"DEMO_DB_MAX_CONNS" default:"10"`
+}
+
+type AppConfig struct {
+	Port       int           `env:"DEMO_PORT" default:"8080"`
+	Debug      bool          `env:"DEMO_DEBUG"`
+	Timeout    time.Duration `env:"DEMO_TIMEOUT" default:"5s"`
+	SampleRate float64       `env:"DEMO_SAMPLE_RATE" default:"0.1"`
+	Region     string        `env:"DEMO_REGION"`
+	DB         DBConfig
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Struct Tags in Practice")
+	fmt.Println("════════════════════════════════════════")
+
+	// ── Validate ──────────────────────────────────────────────────────────
+	fmt.Println("\n── Validate (valid input) ──")
+	good := SignupForm{
+		Username: "gopher",
+		Email:    "gopher@golang.org",
+		Age:      30,
+		Address:  Address{City: "Pune", Zip: "41100"},
+	}
+	fmt.Printf("  Validate(good): %v\n", Validate(good))
+
+	fmt.Println("\n── Validate (every field wrong) ──")
+	bad := &SignupForm{
+		Username: "go",
+		Email:    "not-an-email",
+		Age:      7,
+		Tags:     []string{"a", "b", "c", "d"},
+		Address:  Address{Zip: "123"},
+		Billing:  &Address{City: "Delhi"},
+	}
+	err := Validate(bad)
+	var me *MultiError
+	if errors.As(err, &me) {
+		for _, e := range me.Errors {
+			var ve *ValidationError
+			errors.As(e, &ve)
+			fmt.Printf("  %-16s %s\n", ve.Field, ve.Message)
+		}
+	}
+
+	fmt.Printf("\n  Validate(42): %v\n", Validate(42))
+
+	// ── StructDiff ────────────────────────────────────────────────────────
+	fmt.Println("\n── StructDiff ──")
+	before := good
+	after := good
+	after.Age = 31
+	after.Address.City = "Mumbai"
+	after.Tags = []string{"vip"}
+	changes, _ := StructDiff(before, after)
+	fmt.Printf("  scalar + nested + slice change: %v\n", changes)
+	same, _ := StructDiff(before, before)
+	fmt.Printf("  unchanged: %v (len %d)\n", same, len(same))
+	_, err = StructDiff(before, Address{})
+	fmt.Printf("  different types: %v\n", err)
+
+	type Rec struct {
+		ID        int
+		UpdatedAt time.Time // no exported fields — compared as a whole
+	}
+	t0 := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	changes, _ = StructDiff(Rec{1, t0}, Rec{1, t0.Add(time.Hour)})
+	fmt.Printf("  time.Time field changed: %v\n", changes)
+	changes, _ = StructDiff(Rec{1, t0}, Rec{1, t0.In(time.FixedZone("IST", 5*3600+1800))})
+	fmt.Printf("  same instant, other zone: %v (len %d)\n", changes, len(changes))
+	_, err = StructDiff((*Rec)(nil), (*Rec)(nil))
+	fmt.Printf("  nil pointers: %v\n", err)
+	_, err = StructDiff(nil, Rec{})
+	fmt.Printf("  nil interface: %v\n", err)
+
+	// ── LoadConfig ────────────────────────────────────────────────────────
+	fmt.Println("\n── LoadConfig (env + default tags) ──")
+	setEnv := func(kv map[string]string) (restore func()) {
+		for k, v := range kv {
+			os.Setenv(k, v)
+		}
+		return func() {
+			for k := range kv {
+				os.Unsetenv(k)
+			}
+		}
+	}
+
+	restore := setEnv(map[string]string{"DEMO_PORT": "9090", "DEMO_DEBUG": "true", "DEMO_DB_MAX_CONNS": "25"})
+	cfg := AppConfig{Region: "eu-west-1"} // pre-filled: no env var, no default → kept
+	err = LoadConfig(&cfg)
+	restore()
+	fmt.Printf("  env + defaults: %+v err=%v\n", cfg, err)
+
+	restore = setEnv(map[string]string{
+		"DEMO_PORT":         "eighty",
+		"DEMO_TIMEOUT":      "5 seconds",
+		"DEMO_DB_MAX_CONNS": "300", // overflows uint8
+	})
+	err = LoadConfig(&AppConfig{})
+	restore()
+	if errors.As(err, &me) {
+		fmt.Printf("  %d fields failed:\n", len(me.Errors))
+		for _, e := range me.Errors {
+			fmt.Printf("    %v\n", e)
+		}
+		fmt.Printf("  errors.Is(err, strconv.ErrSyntax): %v\n", errors.Is(err, strconv.ErrSyntax))
+	}
+
+	fmt.Printf("  LoadConfig(AppConfig{}) (not a pointer): %v\n", LoadConfig(AppConfig{}))
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  Validate: required/min/max/email rules read from struct tags")
+	fmt.Println("  Validate collects ALL failures into *MultiError of *ValidationError")
+	fmt.Println("  Nested structs recurse with dotted field paths")
+	fmt.Println("  StructDiff: field path → new value for every changed exported field")
+	fmt.Println("  LoadConfig: env var → default tag → existing value; all parse errors collected")
+	fmt.Println("  LoadConfig's *MultiError holds wrapped strconv/time errors, not *ValidationError")
+}
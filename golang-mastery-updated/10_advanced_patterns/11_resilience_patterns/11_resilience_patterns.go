@@ -0,0 +1,426 @@
+// FILE: 10_advanced_patterns/11_resilience_patterns/11_resilience_patterns.go
+// TOPIC: Resilience Patterns — retries that return values, fallbacks, throttled logging
+//
+// Run: go run 10_advanced_patterns/11_resilience_patterns/11_resilience_patterns.go
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ── RetryWithResult — retry an operation that produces a value ────────────────
+// A plain retry(func() error) forces callers to smuggle the result out through
+// a captured variable. Most real operations (fetch a user, open a conn)
+// return something, so the retry helper should too.
+//
+//   - fn receives the 1-based attempt number (useful for logging/metrics)
+//   - onRetry (optional, may be nil) runs between a failure and the next attempt
+//   - the context is checked before every attempt; a cancelled context stops
+//     the loop and returns ctx.Err() wrapped together with the last failure
+//   - when all attempts fail, the LAST result and error are returned
+
+func RetryWithResult[T any](
+	ctx context.Context,
+	maxAttempts int,
+	fn func(attempt int) (T, error),
+	onRetry func(attempt int, err error),
+) (T, error) {
+	var (
+		result T
+		err    error
+	)
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err == nil {
+				return result, ctxErr
+			}
+			return result, fmt.Errorf("%w (last error: %w)", ctxErr, err)
+		}
+		result, err = fn(attempt)
+		if err == nil {
+			return result, nil
+		}
+		if attempt < maxAttempts && onRetry != nil {
+			onRetry(attempt, err)
+		}
+	}
+	if err == nil {
+		err = errors.New("retry: maxAttempts must be at least 1")
+	}
+	return result, fmt.Errorf("after %d attempts: %w", maxAttempts, err)
+}
+
+// ── RetryPolicy — one configurable backoff policy ─────────────────────────────
+// Retrying immediately hammers a struggling dependency; retrying on a fixed
+// interval makes every client retry in lockstep. Exponential backoff with
+// jitter fixes both:
+//
+//   delay(n) = min(BaseDelay · Multiplier^(n-1), MaxDelay)   n = retry number
+//   with Jitter j, the delay is drawn from [delay·(1-j), delay]
+//
+// Jitter only ever SHORTENS a delay, so MaxDelay stays a hard cap.
+// The zero RetryPolicy is usable; unset fields fall back to the defaults
+// below (Jitter 0 means no jitter). The sleep and random-number hooks are
+// unexported so the demo can swap in a fake clock.
+
+const (
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 100 * time.Millisecond
+	defaultMaxDelay    = 10 * time.Second
+	defaultMultiplier  = 2.0
+)
+
+type RetryPolicy struct {
+	MaxAttempts int           // total tries, including the first
+	BaseDelay   time.Duration // delay before the first retry
+	MaxDelay    time.Duration // cap for any single delay
+	Multiplier  float64       // growth per retry; values < 1 are treated as unset
+	Jitter      float64       // 0..1, fraction of each delay that is randomized
+
+	sleep  func(ctx context.Context, d time.Duration) error // nil → real timer
+	random func() float64                                   // nil → math/rand
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = defaultBaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = defaultMaxDelay
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = defaultMultiplier
+	}
+	p.Jitter = min(max(p.Jitter, 0), 1)
+	if p.sleep == nil {
+		p.sleep = sleepContext
+	}
+	if p.random == nil {
+		p.random = rand.Float64
+	}
+	return p
+}
+
+// Backoff returns the delay before retry number n (1 = the first retry).
+func (p RetryPolicy) Backoff(n int) time.Duration {
+	p = p.withDefaults()
+	d := float64(p.BaseDelay) * math.Pow(p.Multiplier, float64(n-1))
+	d = min(d, float64(p.MaxDelay)) // also tames +Inf for huge n
+	d -= d * p.Jitter * p.random()
+	return time.Duration(d)
+}
+
+// Execute runs fn until it succeeds, MaxAttempts is reached, or ctx is done.
+// A cancelled ctx interrupts a backoff sleep immediately; the returned error
+// then wraps both ctx.Err() and the last failure, like RetryWithResult.
+func (p RetryPolicy) Execute(ctx context.Context, fn func() error) error {
+	p = p.withDefaults()
+	var err error
+	for attempt := 1; attempt <= p.MaxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt == p.MaxAttempts {
+			break
+		}
+		if ctxErr := p.sleep(ctx, p.Backoff(attempt)); ctxErr != nil {
+			return fmt.Errorf("%w (last error: %w)", ctxErr, err)
+		}
+	}
+	return fmt.Errorf("after %d attempts: %w", p.MaxAttempts, err)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ── WithFallback — degrade instead of fail ───────────────────────────────────
+// When the primary source is slow or down, serve something acceptable: a
+// cached copy, a default, a smaller result. Pairs naturally with a circuit
+// breaker (09_state_machine): the breaker decides "don't even try", the
+// fallback decides "what to return instead".
+//
+// primary gets a context that expires after timeout, so a well-behaved
+// primary returns promptly and its goroutine exits — WithFallback waits for
+// nothing after the deadline but doesn't leak either. fallback is called on
+// a primary error OR timeout; if it fails too, both errors are returned.
+// If the PARENT ctx is cancelled, that's the caller giving up: its error is
+// returned and fallback is not run.
+
+func WithFallback[T any](
+	ctx context.Context,
+	timeout time.Duration,
+	primary func(context.Context) (T, error),
+	fallback func() (T, error),
+) (T, error) {
+	pctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel() // tells primary to stop if we return before it does
+
+	type result struct {
+		val T
+		err error
+	}
+	done := make(chan result, 1) // buffered: primary never blocks on send
+	go func() {
+		v, err := primary(pctx)
+		done <- result{v, err}
+	}()
+
+	var primaryErr error
+	select {
+	case r := <-done:
+		if r.err == nil {
+			return r.val, nil
+		}
+		primaryErr = r.err
+	case <-pctx.Done():
+		primaryErr = pctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+	v, err := fallback()
+	if err != nil {
+		return v, fmt.Errorf("fallback failed: %w (primary: %w)", err, primaryErr)
+	}
+	return v, nil
+}
+
+// ── ThrottledLogger — one line per key per interval ──────────────────────────
+// A breaker that flaps open every few milliseconds, or a retry loop around a
+// dead dependency, can write the same line thousands of times a second and
+// bury everything else. ThrottledLogger lets the FIRST occurrence of a key
+// through, swallows repeats until interval has passed, then lets the next one
+// through with a count of what was swallowed:
+//
+//   t=0s    breaker open (payments)
+//   t=0-5s  ... 412 more, suppressed ...
+//   t=5s    breaker open (payments) (suppressed 412 similar)
+//
+// The key, not the message text, decides what counts as "the same": messages
+// carrying a request ID still collapse if they share a key. Suppressed counts
+// are only reported on the next emission — a burst that never recurs is not
+// flushed. One map entry is kept per key seen, so keys should be a small,
+// fixed set (an error class, a dependency name), not per-request values.
+
+// Logger is the same level + message interface as the adapter example in
+// 02_design_patterns_structural.
+type Logger interface {
+	Log(level, message string)
+}
+
+// LoggerFunc lets a plain function act as a Logger, like http.HandlerFunc.
+type LoggerFunc func(level, message string)
+
+func (f LoggerFunc) Log(level, message string) { f(level, message) }
+
+type throttleState struct {
+	lastEmit   time.Time
+	suppressed int
+}
+
+type ThrottledLogger struct {
+	next     Logger
+	interval time.Duration
+
+	mu   sync.Mutex
+	keys map[string]*throttleState
+	now  func() time.Time // the demo swaps in a fake clock
+}
+
+func NewThrottledLogger(next Logger, interval time.Duration) *ThrottledLogger {
+	return &ThrottledLogger{next: next, interval: interval, keys: make(map[string]*throttleState), now: time.Now}
+}
+
+// Log emits msg unless key was emitted less than interval ago.
+func (t *ThrottledLogger) Log(level, key, msg string) {
+	t.mu.Lock()
+	ts := t.now()
+	st, ok := t.keys[key]
+	if ok && ts.Sub(st.lastEmit) < t.interval {
+		st.suppressed++
+		t.mu.Unlock()
+		return
+	}
+	if !ok {
+		st = &throttleState{}
+		t.keys[key] = st
+	}
+	if st.suppressed > 0 {
+		msg = fmt.Sprintf("%s (suppressed %d similar)", msg, st.suppressed)
+	}
+	st.lastEmit, st.suppressed = ts, 0
+	t.mu.Unlock()
+
+	t.next.Log(level, msg) // outside the lock: a slow sink doesn't block other keys
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: Resilience Patterns")
+	fmt.Println("════════════════════════════════════════")
+
+	ctx := context.Background()
+	logRetry := func(attempt int, err error) {
+		fmt.Printf("    retry hook: attempt %d failed: %v\n", attempt, err)
+	}
+
+	// ── RetryWithResult: late success ─────────────────────────────────────
+	fmt.Println("\n── RetryWithResult (succeeds on attempt 3) ──")
+	user, err := RetryWithResult(ctx, 5, func(attempt int) (string, error) {
+		if attempt < 3 {
+			return "", fmt.Errorf("503 from user-service")
+		}
+		return "alice", nil
+	}, logRetry)
+	fmt.Printf("  result=%q err=%v\n", user, err)
+
+	// ── RetryWithResult: every attempt fails ──────────────────────────────
+	fmt.Println("\n── RetryWithResult (all attempts fail) ──")
+	errDown := errors.New("connection refused")
+	port, err := RetryWithResult(ctx, 3, func(attempt int) (int, error) {
+		return attempt * 1000, errDown // last result is still returned
+	}, logRetry)
+	fmt.Printf("  result=%d err=%v errors.Is(errDown)=%v\n", port, err, errors.Is(err, errDown))
+
+	// ── RetryWithResult: cancelled between attempts ──────────────────────
+	fmt.Println("\n── RetryWithResult (context cancelled) ──")
+	cctx, cancel := context.WithCancel(ctx)
+	_, err = RetryWithResult(cctx, 10, func(attempt int) (bool, error) {
+		if attempt == 2 {
+			cancel() // e.g. the request was abandoned by the client
+		}
+		return false, errDown
+	}, nil)
+	fmt.Printf("  err=%v errors.Is(context.Canceled)=%v\n", err, errors.Is(err, context.Canceled))
+
+	// ── RetryPolicy: backoff sequence on a fake clock ────────────────────
+	fmt.Println("\n── RetryPolicy (fake clock) ──")
+	var slept []time.Duration
+	fakeSleep := func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d) // record instead of waiting
+		return ctx.Err()
+	}
+	policy := RetryPolicy{MaxAttempts: 6, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, sleep: fakeSleep}
+	err = policy.Execute(ctx, func() error { return errDown })
+	fmt.Printf("  6 failing attempts → sleeps %v\n", slept)
+	fmt.Printf("  err=%v\n", err)
+
+	slept = nil
+	jittery := policy
+	jittery.Jitter = 0.5
+	jittery.random = rand.New(rand.NewSource(1)).Float64
+	jittery.Execute(ctx, func() error { return errDown })
+	inRange := true
+	for i, d := range slept {
+		full := policy.Backoff(i + 1)
+		inRange = inRange && d >= full/2 && d <= full
+	}
+	fmt.Printf("  jitter 0.5 → sleeps %v\n", slept)
+	fmt.Printf("  each within [delay/2, delay]: %v\n", inRange)
+
+	var zero RetryPolicy
+	zero.sleep = fakeSleep
+	slept = nil
+	calls := 0
+	err = zero.Execute(ctx, func() error {
+		calls++
+		if calls < 2 {
+			return errDown
+		}
+		return nil
+	})
+	fmt.Printf("  zero-value policy: %d calls, sleeps %v, err=%v\n", calls, slept, err)
+
+	// ── RetryPolicy: real sleep, cancelled mid-backoff ───────────────────
+	fmt.Println("\n── RetryPolicy (context cancelled during backoff) ──")
+	tctx, tcancel := context.WithTimeout(ctx, 30*time.Millisecond)
+	start := time.Now()
+	err = RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second}.Execute(tctx, func() error { return errDown })
+	tcancel()
+	fmt.Printf("  1s backoff, 30ms deadline: returned in <500ms=%v\n", time.Since(start) < 500*time.Millisecond)
+	fmt.Printf("  err=%v\n", err)
+	fmt.Printf("  errors.Is DeadlineExceeded=%v, errDown=%v\n",
+		errors.Is(err, context.DeadlineExceeded), errors.Is(err, errDown))
+
+	// ── WithFallback ──────────────────────────────────────────────────────
+	fmt.Println("\n── WithFallback ──")
+	cached := func() (string, error) { return "prices@cache(5m old)", nil }
+	fast := func(ctx context.Context) (string, error) { return "prices@live", nil }
+	v, err := WithFallback(ctx, 50*time.Millisecond, fast, cached)
+	fmt.Printf("  primary ok:      %q err=%v\n", v, err)
+
+	primaryExited := make(chan error, 1)
+	slow := func(ctx context.Context) (string, error) {
+		select {
+		case <-time.After(time.Second):
+			return "prices@live", nil
+		case <-ctx.Done():
+			primaryExited <- ctx.Err() // observes cancellation — no leak
+			return "", ctx.Err()
+		}
+	}
+	start = time.Now()
+	v, err = WithFallback(ctx, 20*time.Millisecond, slow, cached)
+	fmt.Printf("  primary timeout: %q err=%v (after <200ms: %v)\n", v, err, time.Since(start) < 200*time.Millisecond)
+	fmt.Printf("  primary goroutine saw: %v\n", <-primaryExited)
+
+	broken := func(ctx context.Context) (string, error) { return "", errDown }
+	v, err = WithFallback(ctx, 50*time.Millisecond, broken, cached)
+	fmt.Printf("  primary error:   %q err=%v\n", v, err)
+
+	_, err = WithFallback(ctx, 50*time.Millisecond, broken, func() (string, error) {
+		return "", errors.New("cache empty")
+	})
+	fmt.Printf("  both fail:       err=%v (Is errDown=%v)\n", err, errors.Is(err, errDown))
+
+	// ── ThrottledLogger ───────────────────────────────────────────────────
+	fmt.Println("\n── ThrottledLogger (5s interval, fake clock) ──")
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var emitted int
+	sink := LoggerFunc(func(level, msg string) {
+		emitted++
+		fmt.Printf("    t=%-3s [%s] %s\n", clock.Format("5s"), level, msg)
+	})
+	tl := NewThrottledLogger(sink, 5*time.Second)
+	tl.now = func() time.Time { return clock }
+
+	// 100 breaker trips over 10s (one every 100ms) plus an occasional other key.
+	total := 0
+	for i := 0; i < 100; i++ {
+		tl.Log("WARN", "breaker:payments", "circuit open for payments")
+		total++
+		if i%40 == 0 {
+			tl.Log("ERROR", "db:timeout", "db query timed out")
+			total++
+		}
+		clock = clock.Add(100 * time.Millisecond)
+	}
+	tl.Log("WARN", "breaker:payments", "circuit open for payments")
+	total++
+	fmt.Printf("  %d Log calls → %d lines written\n", total, emitted)
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  RetryWithResult: returns the value, passes attempt #, onRetry hook")
+	fmt.Println("  RetryPolicy: exponential backoff, capped, jitter only shortens; ctx-aware")
+	fmt.Println("  WithFallback: primary under timeout, fallback on error or timeout")
+	fmt.Println("  ThrottledLogger: first per key, then one per interval with suppressed count")
+}
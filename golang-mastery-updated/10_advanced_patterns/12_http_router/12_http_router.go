@@ -0,0 +1,198 @@
+// FILE: 10_advanced_patterns/12_http_router/12_http_router.go
+// TOPIC: HTTP Router — method + path matching with :params, stdlib only
+//
+// Run: go run 10_advanced_patterns/12_http_router/12_http_router.go
+//
+// Server-side counterpart to the functional-options HTTPClient in
+// 01_design_patterns_creational. Demos use httptest — no network needed.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ── PATH PARAMS IN THE REQUEST CONTEXT ────────────────────────────────────────
+// Same typed-key trick as 06_concurrency/10_context_package: an unexported
+// key type means no other package can collide with (or read) our value
+// except through the accessor below.
+
+type contextKeyType string
+
+const paramsKey contextKeyType = "routeParams"
+
+// Param returns the value of a :name path segment, or "" if absent.
+func Param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey).(map[string]string)
+	return params[name]
+}
+
+// ── ROUTER ────────────────────────────────────────────────────────────────────
+// Routes are matched segment by segment:
+//
+//   pattern  /users/:id/posts/:post
+//   path     /users/42/posts/7        → id=42, post=7
+//
+// Literal segments must match exactly; ":name" segments match anything
+// non-empty. First registered match wins — so ORDER MATTERS: register
+// /users/me before /users/:id, or ":id" captures "me" and the literal route
+// never runs.
+//
+// Status codes follow HTTP semantics:
+//   - no pattern matches the PATH         → 404 Not Found
+//   - path matches, but not for this METHOD → 405 Method Not Allowed,
+//     with an Allow header listing the methods that would have worked
+//
+// Router implements http.Handler, so it plugs into http.Server (and any
+// graceful-shutdown wrapper) like a ServeMux.
+
+type route struct {
+	method   string
+	segments []string
+	handler  http.HandlerFunc
+}
+
+type Router struct {
+	routes []route
+}
+
+func NewRouter() *Router { return &Router{} }
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// Handle registers h for method and path pattern.
+func (rt *Router) Handle(method, path string, h http.HandlerFunc) {
+	rt.routes = append(rt.routes, route{method: method, segments: splitPath(path), handler: h})
+}
+
+// match reports whether segments fit the route pattern, returning captured params.
+func (r route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+	var params map[string]string
+	for i, pat := range r.segments {
+		if name, ok := strings.CutPrefix(pat, ":"); ok {
+			if segments[i] == "" {
+				return nil, false
+			}
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[name] = segments[i]
+			continue
+		}
+		if pat != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	segments := splitPath(req.URL.Path)
+	var allowed []string
+	for _, r := range rt.routes {
+		params, ok := r.match(segments)
+		if !ok {
+			continue
+		}
+		if r.method != req.Method {
+			if !slices.Contains(allowed, r.method) { // several routes may share a method
+				allowed = append(allowed, r.method)
+			}
+			continue
+		}
+		ctx := context.WithValue(req.Context(), paramsKey, params)
+		r.handler(w, req.WithContext(ctx))
+		return
+	}
+	if len(allowed) > 0 {
+		sort.Strings(allowed)
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	http.NotFound(w, req)
+}
+
+func main() {
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("  Topic: HTTP Router")
+	fmt.Println("════════════════════════════════════════")
+
+	rt := NewRouter()
+	// Literal before param: registered first, so it wins for GET /users/me.
+	rt.Handle(http.MethodGet, "/users/me", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "current user")
+	})
+	rt.Handle(http.MethodGet, "/users/:id", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "get user %s", Param(r, "id"))
+	})
+	rt.Handle(http.MethodDelete, "/users/:id", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	rt.Handle(http.MethodGet, "/users/:id/posts/:post", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "user %s, post %s", Param(r, "id"), Param(r, "post"))
+	})
+	rt.Handle(http.MethodGet, "/health", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	})
+
+	do := func(method, path string) {
+		rec := httptest.NewRecorder()
+		rt.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		body := strings.TrimSpace(rec.Body.String())
+		line := fmt.Sprintf("  %-6s %-22s → %d %q", method, path, rec.Code, body)
+		if allow := rec.Header().Get("Allow"); allow != "" {
+			line += fmt.Sprintf(" Allow: %s", allow)
+		}
+		fmt.Println(line)
+	}
+
+	// ── Matching and params ───────────────────────────────────────────────
+	fmt.Println("\n── Parameterized routes ──")
+	do(http.MethodGet, "/users/42")
+	do(http.MethodGet, "/users/42/")
+	do(http.MethodGet, "/users/me") // literal route, not :id="me"
+	do(http.MethodGet, "/users/7/posts/99")
+	do(http.MethodDelete, "/users/42")
+	do(http.MethodGet, "/health")
+
+	// ── 404 vs 405 ────────────────────────────────────────────────────────
+	fmt.Println("\n── 404 / 405 ──")
+	do(http.MethodPost, "/users/42")
+	do(http.MethodPost, "/users/me") // matches /users/:id AND /users/me — GET listed once
+	do(http.MethodGet, "/users")
+	do(http.MethodGet, "/nope")
+
+	// ── Behind a real server ──────────────────────────────────────────────
+	fmt.Println("\n── Behind httptest.Server ──")
+	srv := httptest.NewServer(rt)
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/users/alice")
+	if err != nil {
+		fmt.Printf("  error: %v\n", err)
+	} else {
+		b, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		fmt.Printf("  GET /users/alice → %d %q\n", resp.StatusCode, b)
+	}
+
+	fmt.Println("\n─── SUMMARY ────────────────────────────────")
+	fmt.Println("  Router: segment matching, :params via typed context key")
+	fmt.Println("  Path mismatch → 404; method mismatch → 405 + Allow header")
+}